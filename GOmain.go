@@ -2,215 +2,538 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"github.com/fsnotify/fsnotify" // Dosya sistemi değişikliklerini izlemek için
 	"io"
-	"log"
+	"log/slog"
 	"os"
+	"os/signal"
 	"path/filepath"
-	"strings"
 	"sync"
+	"syscall"
 	"time"
+
+	"github.com/ca1ik/GO-Cloud/filters"
+	"github.com/ca1ik/GO-Cloud/fswatcher"
+	"github.com/ca1ik/GO-Cloud/httpapi"
+	"github.com/ca1ik/GO-Cloud/metrics"
+	"github.com/ca1ik/GO-Cloud/model"
+	"github.com/ca1ik/GO-Cloud/parsers"
+	"github.com/ca1ik/GO-Cloud/sinks"
 )
 
-// LogEntry struct'ı, okunacak her log satırını temsil eder
-type LogEntry struct {
-	Timestamp time.Time `json:"timestamp"`
-	Service   string    `json:"service"` // Hangi servisten geldiği (dosya adından tahmin edilebilir)
-	Message   string    `json:"message"`
+// LogPattern, izlenecek tek bir glob desenini, o desenden üretilen dosyalar
+// için kullanılacak servis etiketi şablonunu ve hangi Parser/Filter zincirinin
+// uygulanacağını tanımlar.
+type LogPattern struct {
+	Glob string `json:"glob" yaml:"glob"` // Örn: "./logs/**/*.log" ya da "/var/log/nginx/access.*.log"
+
+	// ServiceLabel, "{dir}-{basename}" gibi bir şablondur. Boş bırakılırsa
+	// eski TrimSuffix(Base(path), Ext(path)) sezgisine geri düşülür.
+	ServiceLabel string `json:"serviceLabel" yaml:"serviceLabel"`
+
+	// Parser, bu desenle eşleşen dosyalar için kullanılacak ayrıştırıcıyı seçer.
+	// Boş bırakılırsa RawParser (eski davranış: ham satır, time.Now()) kullanılır.
+	Parser parsers.Config `json:"parser" yaml:"parser"`
+
+	// Filters, Parser'dan çıkan her LogEntry üzerinde sink'e gönderilmeden
+	// önce sırayla uygulanan dönüşüm/eleme zinciridir.
+	Filters []filters.Config `json:"filters" yaml:"filters"`
+
+	compiled        *fswatcher.Pattern
+	compiledParser  parsers.Parser
+	compiledFilters filters.Chain
 }
 
 // CollectorConfig, toplayıcının yapılandırma ayarlarını tutar
 type CollectorConfig struct {
-	LogDirectory    string        // İzlenecek ana log dizini
-	FilePattern     string        // İzlenecek dosya deseni (örn: "*.log")
-	PollingInterval time.Duration // Yeni dosyaları kontrol etme aralığı
+	LogPatterns     []LogPattern  `json:"logPatterns" yaml:"logPatterns"`         // İzlenecek glob desenleri (birden fazla olabilir)
+	PollingInterval time.Duration `json:"pollingInterval" yaml:"pollingInterval"` // Yeni dosyaları kontrol etme aralığı
+
+	// RotatedSuffixes, yerinde sıkıştırılmış (ör. logrotate + gzip) rotasyon
+	// dosyalarını tanımak için kullanılır (".1", ".gz", vb.). scanAndWatchFiles,
+	// bu soneklerden biriyle biten dosyaları izlemeye almaz. RENAME/REMOVE
+	// olaylarının işlenmesinde (handleRemoval) bu liste kullanılmaz.
+	RotatedSuffixes []string `json:"rotatedSuffixes" yaml:"rotatedSuffixes"`
+
+	Sink  sinks.Config    `json:"sink" yaml:"sink"`
+	Queue SinkQueueConfig `json:"queue" yaml:"queue"`
+
+	// MetricsAddr, /metrics, /healthz ve /debug/pprof uç noktalarını sunan
+	// teşhis HTTP sunucusunun dinleyeceği adrestir. Boşsa sunucu başlatılmaz.
+	MetricsAddr string `json:"metricsAddr" yaml:"metricsAddr"`
+
+	// CheckpointPath, izlenen dosyaların (dev, ino, pos) durumunun kalıcı
+	// olarak saklanacağı dosyanın yoludur. Boşsa checkpoint devre dışıdır ve
+	// toplayıcı her yeniden başlatıldığında sona konumlanma sezgisine döner.
+	CheckpointPath string `json:"checkpointPath" yaml:"checkpointPath"`
+
+	// CheckpointInterval, checkpoint dosyasının ne sıklıkla diske yazılacağını belirler.
+	CheckpointInterval time.Duration `json:"checkpointInterval" yaml:"checkpointInterval"`
 }
 
-func main() {
-	// Yapılandırma
-	config := CollectorConfig{
-		LogDirectory:    "./logs",         // Örnek log dizini, bu dizini oluşturmanız gerekecek
-		FilePattern:     "*.log",          // Sadece .log uzantılı dosyaları izle
-		PollingInterval: 10 * time.Second, // 10 saniyede bir yeni dosyaları kontrol et
+// defaultConfig, yapılandırma dosyası verilmediğinde ya da bulunamadığında
+// kullanılan, bağımlılık gerektirmeyen (StdoutSink) yapılandırmadır.
+func defaultConfig() CollectorConfig {
+	return CollectorConfig{
+		LogPatterns: []LogPattern{
+			{Glob: "./logs/**/*.log"},
+		},
+		PollingInterval:    10 * time.Second,
+		RotatedSuffixes:    []string{".1", ".gz"},
+		Sink:               sinks.Config{Type: "stdout"},
+		MetricsAddr:        ":9109",
+		CheckpointPath:     "checkpoint.json",
+		CheckpointInterval: 10 * time.Second,
 	}
+}
+
+func main() {
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
 
-	// Log dizinini oluştur (eğer yoksa)
-	if _, err := os.Stat(config.LogDirectory); os.IsNotExist(err) {
-		log.Printf("Log dizini '%s' bulunamadı, oluşturuluyor...", config.LogDirectory)
-		if err := os.MkdirAll(config.LogDirectory, 0755); err != nil {
-			log.Fatalf("Log dizini oluşturulamadı: %v", err)
+	configPath := flag.String("config", "config.yaml", "Yapılandırma dosyasının yolu (YAML ya da JSON)")
+	fromBeginning := flag.Bool("from-beginning", false, "Checkpoint'i olmayan dosyaları sona konumlanmak yerine baştan oku (ilk dolgu)")
+	flag.Parse()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	config, err := loadConfig(*configPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			slog.Warn("yapılandırma dosyası bulunamadı, varsayılan (stdout sink) yapılandırma kullanılıyor", "path", *configPath)
+			config = defaultConfig()
+			if err := compilePatterns(config.LogPatterns); err != nil {
+				slog.Error("desenler derlenemedi", "error", err)
+				os.Exit(1)
+			}
+		} else {
+			slog.Error("yapılandırma yüklenemedi", "error", err)
+			os.Exit(1)
 		}
 	}
 
-	fmt.Printf("GoLogInsight Log Toplayıcı başlatılıyor...\n")
-	fmt.Printf("İzlenecek Dizin: %s\n", config.LogDirectory)
-	fmt.Printf("Dosya Deseni: %s\n", config.FilePattern)
-	fmt.Printf("Dosya Gezgini/Yol: %s\n", config.filePath)
+	slog.Info("GoLogInsight Log Toplayıcı başlatılıyor")
+	for _, p := range config.LogPatterns {
+		slog.Info("izlenecek desen", "glob", p.Glob)
+	}
+
+	registry := metrics.New()
+
+	if config.MetricsAddr != "" {
+		metricsServer := httpapi.New(config.MetricsAddr, registry)
+		metricsServer.Start(func(err error) {
+			slog.Error("teşhis HTTP sunucusu çöktü", "error", err)
+		})
+		defer metricsServer.Shutdown(context.Background())
+		slog.Info("teşhis HTTP sunucusu dinliyor", "addr", config.MetricsAddr)
+	}
+
+	checkpoints, err := loadCheckpointStore(config.CheckpointPath)
+	if err != nil {
+		slog.Error("checkpoint dosyası okunamadı", "path", config.CheckpointPath, "error", err)
+		os.Exit(1)
+	}
+
+	sink, err := sinks.New(config.Sink)
+	if err != nil {
+		slog.Error("sink oluşturulamadı", "error", err)
+		os.Exit(1)
+	}
+
+	queue, err := newSinkQueue(sink, config.Queue, registry)
+	if err != nil {
+		slog.Error("sink kuyruğu oluşturulamadı", "error", err)
+		os.Exit(1)
+	}
+	defer queue.Close()
 
-	// Dosya izleyici başlatma
-	watcher, err := fsnotify.NewWatcher()
+	// Dosya sistemi izleyicisini (inotify/kqueue/polling) başlat
+	backend, err := fswatcher.New(fswatcher.Options{PollInterval: config.PollingInterval})
 	if err != nil {
-		log.Fatalf("Dosya izleyici oluşturulamadı: %v", err)
+		slog.Error("dosya izleyici oluşturulamadı", "error", err)
+		os.Exit(1)
 	}
-	defer watcher.Close()
+	defer backend.Close()
+
+	tracker := newFileTracker(registry)
+	var wg sync.WaitGroup // processFileChanges goroutine'lerinin bitmesini beklemek için
 
-	// Mevcut log dosyalarını ve açık dosya tanıtıcılarını tutmak için map
-	// Her dosya için kendi okuyucusunu (scanner) ve okunan son bayt pozisyonunu tutacağız
-	fileReaders := make(map[string]*bufio.Scanner)
-	filePointers := make(map[string]int64) // Her dosyanın okunan son bayt pozisyonu
+	// Desenlerin statik dizinlerini izlemeye başla ve mevcut dosyaları tara
+	if err := scanAndWatchFiles(ctx, backend, config, tracker, checkpoints, *fromBeginning); err != nil {
+		slog.Warn("dosya tarama hatası", "error", err)
+	}
+
+	// Yeni dosyaları periyodik olarak tekrar tara (ör. polling backend'de
+	// ya da henüz var olmayan statik dizinlerin sonradan oluşması durumunda)
+	go func() {
+		ticker := time.NewTicker(config.PollingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := scanAndWatchFiles(ctx, backend, config, tracker, checkpoints, *fromBeginning); err != nil {
+					slog.Warn("dosya tarama hatası", "error", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
 
-	var wg sync.WaitGroup // Goroutine'lerin bitmesini beklemek için
+	// parsers paketi, her parser türü için ayrı bir paket düzeyinde atomic
+	// sayaç tutar (parsers paketinin metrics'e bağımlı olmaması için); burada
+	// periyodik olarak örnekleyip ParseErrorsTotal'a, türe göre etiketlenmiş
+	// fark olarak yansıtıyoruz.
+	go reportParseErrors(ctx, registry)
 
-	// Yeni dosyaları ve mevcut dosyaları periyodik olarak kontrol et
+	// ScannerBacklogBytes, tüm izlenen dosyalar için (boyut - pozisyon)
+	// toplamıdır; downstream'in ne kadar geride kaldığını gösterir.
 	go func() {
+		ticker := time.NewTicker(config.PollingInterval)
+		defer ticker.Stop()
 		for {
-			err := scanAndWatchFiles(watcher, config, fileReaders, filePointers, &wg)
-			if err != nil {
-				log.Printf("Dosya tarama hatası: %v", err)
+			select {
+			case <-ticker.C:
+				registry.ScannerBacklogBytes.Set(float64(tracker.backlogBytes()))
+			case <-ctx.Done():
+				return
 			}
-			time.Sleep(config.PollingInterval)
 		}
 	}()
 
-	// Dosya sistemi olaylarını dinle
+	// filePointers'ı periyodik olarak ve kapanışta diske yazar.
+	go checkpoints.runPeriodic(tracker, config.CheckpointInterval, ctx.Done())
+
+	// Dosya sistemi olaylarını SIGINT/SIGTERM gelene kadar dinle.
+runLoop:
 	for {
 		select {
-		case event, ok := <-watcher.Events:
+		case event, ok := <-backend.Events():
 			if !ok {
-				return // Kanal kapandı
-			}
-			if event.Op&fsnotify.Write == fsnotify.Write {
-				// Dosyaya yazma olayı tespit edildiğinde
-				// Sadece zaten izlediğimiz dosyalarla ilgileniyoruz
-				if _, exists := fileReaders[event.Name]; exists {
-					wg.Add(1)
-					go processFileChanges(event.Name, fileReaders, filePointers, &wg)
-				}
-			} else if event.Op&fsnotify.Create == fsnotify.Create {
-				// Yeni dosya oluşturulduğunda
-				fmt.Printf("Yeni dosya oluşturuldu: %s\n", event.Name)
-				// Yeni dosyayı watcher'a eklemek ve okumaya başlamak için tekrar tarama tetikle
-				// Bu, scanAndWatchFiles döngüsü tarafından otomatik olarak ele alınacak
+				break runLoop // Kanal kapandı
 			}
-		case err, ok := <-watcher.Errors:
+			handleEvent(ctx, config, event, tracker, queue, &wg, registry, checkpoints, *fromBeginning)
+		case err, ok := <-backend.Errors():
 			if !ok {
-				return // Kanal kapandı
+				break runLoop // Kanal kapandı
+			}
+			slog.Warn("watcher hatası", "error", err)
+		case <-ctx.Done():
+			break runLoop
+		}
+	}
+
+	slog.Info("kapanış sinyali alındı, devam eden dosya okumaları bekleniyor")
+	wg.Wait()
+
+	checkpoints.snapshot(tracker)
+	if err := checkpoints.flush(); err != nil {
+		slog.Warn("kapanışta checkpoint diske yazılamadı", "error", err)
+	}
+	slog.Info("toplayıcı kapatıldı")
+}
+
+// reportParseErrors, her parser türü için parsers paketindeki atomic
+// sayaçların artışını periyodik olarak örnekleyip ParseErrorsTotal
+// Prometheus sayacına, o türe ait etiketle fark olarak ekler.
+func reportParseErrors(ctx context.Context, registry *metrics.Registry) {
+	last := make(map[string]uint64)
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for _, kind := range parsers.ParseErrorKinds() {
+				current := parsers.ParseErrorCount(kind)
+				if current > last[kind] {
+					registry.ParseErrorsTotal.WithLabelValues(kind).Add(float64(current - last[kind]))
+					last[kind] = current
+				}
 			}
-			log.Printf("Watcher hatası: %v", err)
+		case <-ctx.Done():
+			return
 		}
 	}
 }
 
-// scanAndWatchFiles, dizindeki mevcut ve yeni log dosyalarını bulur ve izlemeye başlar
-func scanAndWatchFiles(watcher *fsnotify.Watcher, config CollectorConfig,
-	fileReaders map[string]*bufio.Scanner, filePointers map[string]int64, wg *sync.WaitGroup) error {
+// compilePatterns, her LogPattern.Glob alanını ve onunla ilişkili Parser/Filters
+// yapılandırmalarını derler. Geçersiz bir desen ya da parser/filter başlangıçta
+// hata olarak döner.
+func compilePatterns(logPatterns []LogPattern) error {
+	for i := range logPatterns {
+		compiled, err := fswatcher.CompilePattern(logPatterns[i].Glob)
+		if err != nil {
+			return err
+		}
+		logPatterns[i].compiled = compiled
 
-	files, err := filepath.Glob(filepath.Join(config.LogDirectory, config.FilePattern))
-	if err != nil {
-		return fmt.Errorf("dosya deseni okunamadı: %v", err)
+		parser, err := parsers.New(logPatterns[i].Parser)
+		if err != nil {
+			return err
+		}
+		logPatterns[i].compiledParser = parser
+
+		chain, err := filters.Compile(logPatterns[i].Filters)
+		if err != nil {
+			return err
+		}
+		logPatterns[i].compiledFilters = chain
+	}
+	return nil
+}
+
+// matchPattern, verilen yol için eşleşen LogPattern'i (varsa) döner.
+func matchPattern(config CollectorConfig, path string) (LogPattern, bool) {
+	for _, p := range config.LogPatterns {
+		if p.compiled != nil && p.compiled.Match(path) {
+			return p, true
+		}
+	}
+	return LogPattern{}, false
+}
+
+// handleEvent, backend'den gelen tek bir olayı işler: dosya oluşturulduysa
+// açar ve izlemeye başlar, yazıldıysa okuma goroutine'i başlatır, yeniden
+// adlandırıldıysa/silindiyse eski dosya tanıtıcısını EOF'a kadar boşaltıp kapatır.
+func handleEvent(ctx context.Context, config CollectorConfig, event fswatcher.Event, tracker *fileTracker, queue *sinkQueue, wg *sync.WaitGroup, registry *metrics.Registry, checkpoints *checkpointStore, fromBeginning bool) {
+	switch {
+	case event.Op&(fswatcher.Rename|fswatcher.Remove) != 0:
+		handleRemoval(event.Path, tracker, queue, registry)
+
+	case event.Op&fswatcher.Create != 0:
+		info, err := os.Stat(event.Path)
+		if err != nil || info.IsDir() {
+			return
+		}
+		// Yeni oluşturulan dosyanın gerçekten izlediğimiz desenlerden biriyle
+		// eşleştiğini doğrulamadan açmıyoruz; dizin izlendiği için alakasız
+		// dosyalar için de CREATE olayı gelebilir.
+		pattern, ok := matchPattern(config, event.Path)
+		if !ok {
+			return
+		}
+		lock := tracker.processLock(event.Path)
+		lock.Lock()
+		if _, exists := tracker.get(event.Path); !exists {
+			openTracked(tracker, event.Path, pattern.ServiceLabel, pattern.compiledParser, pattern.compiledFilters, checkpoints, fromBeginning)
+		}
+		lock.Unlock()
+
+	case event.Op&fswatcher.Write != 0:
+		if _, exists := tracker.get(event.Path); exists {
+			wg.Add(1)
+			go processFileChanges(ctx, event.Path, tracker, queue, wg, registry)
+		}
+	}
+}
+
+// handleRemoval, bir RENAME/REMOVE olayıyla karşılaşıldığında izlenen dosyayı
+// kapatmadan önce EOF'a kadar okur. Bu, logrotate'in eski dosyayı taşımasından
+// hemen sonra gelebilecek son yazımların kaybolmasını engeller. Dosya, yeni
+// adından (RotatedSuffixes ile eşleşip eşleşmediğinden) bağımsız olarak olay
+// anında bir kez boşaltılıp kapatılır ve tracker'dan silinir; taşınan dosyaya
+// bundan sonra yapılan yazımlar okunmaz. Aynı yolda yeniden oluşması CREATE
+// olayıyla ele alınır.
+//
+// tracker.processLock(path) alınır: aynı yol için hâlâ sürmekte olan bir
+// processFileChanges çağrısı aynı *os.File üzerinde Seek/Scan yapıyor olabilir;
+// bu kilit olmadan burada yapılan Seek/Scan/Close o goroutine'le yarışa girer.
+func handleRemoval(path string, tracker *fileTracker, queue *sinkQueue, registry *metrics.Registry) {
+	lock := tracker.processLock(path)
+	lock.Lock()
+	defer lock.Unlock()
+
+	tf, ok := tracker.get(path)
+	if !ok {
+		return
 	}
+	tracker.delete(path)
+	tf.rotatingAway = true
+
+	slog.Info("dosya yeniden adlandırıldı/silindi (muhtemelen rotasyon)", "path", path)
+	drainAndClose(tf, path, func(line string) {
+		enqueueParsed(queue, tf, path, line, registry)
+	})
+}
+
+// scanAndWatchFiles, her desenin statik dizinini backend'e ekler ve o anda
+// desenle eşleşen mevcut dosyaları bulup izlemeye başlar. ctx zaten iptal
+// edilmişse (kapanış sinyali) tarama yapılmadan dönülür; başlamış bir tarama
+// sonuna kadar sürer.
+func scanAndWatchFiles(ctx context.Context, backend fswatcher.Backend, config CollectorConfig, tracker *fileTracker, checkpoints *checkpointStore, fromBeginning bool) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	watchedDirs := make(map[string]bool)
+
+	for _, pattern := range config.LogPatterns {
+		if pattern.compiled == nil {
+			continue
+		}
+
+		dirs := []string{pattern.compiled.StaticDir}
+		if pattern.compiled.Recursive {
+			// "**" içeren desenler için tüm alt dizinleri de izlemeye al, böylece
+			// o alt dizinlerde oluşturulan dosyalar için de CREATE olayı alırız.
+			_ = filepath.WalkDir(pattern.compiled.StaticDir, func(path string, d os.DirEntry, err error) error {
+				if err != nil || d == nil || !d.IsDir() {
+					return nil
+				}
+				dirs = append(dirs, path)
+				return nil
+			})
+		}
 
-	for _, filePath := range files {
-		// Eğer dosya zaten izlenmiyorsa, watcher'a ekle ve okumaya başla
-		if _, ok := fileReaders[filePath]; !ok {
-			fmt.Printf("İzlemeye başlandı: %s\n", filePath)
-			err := watcher.Add(filePath)
-			if err != nil {
-				log.Printf("Watcher'a dosya eklenemedi '%s': %v", filePath, err)
+		for _, dir := range dirs {
+			if watchedDirs[dir] {
 				continue
 			}
-
-			file, err := os.Open(filePath)
-			if err != nil {
-				log.Printf("Dosya açılamadı '%s': %v", filePath, err)
+			if err := backend.Add(dir); err != nil {
+				slog.Warn("dizin izlenemedi", "dir", dir, "error", err)
 				continue
 			}
+			watchedDirs[dir] = true
+		}
+
+		matches, err := filepath.Glob(pattern.compiled.Raw)
+		if err != nil {
+			return fmt.Errorf("dosya deseni okunamadı: %v", err)
+		}
+		if pattern.compiled.Recursive {
+			matches = matches[:0]
+			_ = filepath.WalkDir(pattern.compiled.StaticDir, func(path string, d os.DirEntry, err error) error {
+				if err != nil || d == nil || d.IsDir() {
+					return nil
+				}
+				matches = append(matches, path)
+				return nil
+			})
+		}
 
-			// Mevcut dosyaları baştan okumak yerine sonundan başla
-			// Bu, toplayıcının her yeniden başlatıldığında eski logları tekrar işlemesini engeller.
-			info, err := file.Stat()
-			if err != nil {
-				log.Printf("Dosya bilgisi alınamadı '%s': %v", filePath, err)
-				file.Close()
+		for _, filePath := range matches {
+			if !pattern.compiled.Match(filePath) {
 				continue
 			}
-			file.Seek(info.Size(), io.SeekStart) // Dosya sonuna git
-
-			fileReaders[filePath] = bufio.NewScanner(file)
-			filePointers[filePath] = info.Size()
+			if isRotatedSuffix(filePath, config.RotatedSuffixes) {
+				continue // Zaten rotasyona uğramış dosyaları yeniden izlemeye alma
+			}
+			lock := tracker.processLock(filePath)
+			lock.Lock()
+			if _, ok := tracker.get(filePath); !ok {
+				openTracked(tracker, filePath, pattern.ServiceLabel, pattern.compiledParser, pattern.compiledFilters, checkpoints, fromBeginning)
+			}
+			lock.Unlock()
 		}
 	}
 	return nil
 }
 
-// processFileChanges, belirli bir dosyadaki yeni satırları okur ve işler
-func processFileChanges(filePath string, fileReaders map[string]*bufio.Scanner,
-	filePointers map[string]int64, wg *sync.WaitGroup) {
+// processFileChanges, belirli bir dosyadaki yeni satırları okur ve işler.
+// Aynı yoldaki dosyanın inode'u değiştiyse (rename-based rotasyon ya da
+// copy-truncate), eski fd'yi boşaltıp kapatır ve yeni dosyayı baştan açar.
+// ctx zaten iptal edilmişse (kapanış) hiç okuma yapmadan döner; başlamış bir
+// okuma turu ise EOF'a kadar sürer.
+func processFileChanges(ctx context.Context, filePath string, tracker *fileTracker, queue *sinkQueue, wg *sync.WaitGroup, registry *metrics.Registry) {
 	defer wg.Done()
 
-	scanner := fileReaders[filePath]
-	file, _ := scanner.Unwrap().(*os.File) // scanner'ın altında yatan *os.File nesnesini al
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Aynı yol için üst üste gelen olaylar farklı goroutine'lerde işlenebilir;
+	// aynı *os.File üzerinde eşzamanlı Seek/Scan'i önlemek için serileştir.
+	lock := tracker.processLock(filePath)
+	lock.Lock()
+	defer lock.Unlock()
 
-	// Dosyanın mevcut boyutunu al
-	info, err := file.Stat()
+	tf, ok := tracker.get(filePath)
+	if !ok {
+		return
+	}
+
+	info, err := os.Stat(filePath)
 	if err != nil {
-		log.Printf("Dosya bilgisi alınamadı '%s': %v", filePath, err)
+		slog.Warn("dosya bilgisi alınamadı", "path", filePath, "error", err)
 		return
 	}
 
-	// Eğer dosya boyutu küçüldüyse (log rotation vb.), başa dön
-	if info.Size() < filePointers[filePath] {
-		fmt.Printf("Dosya boyutu küçüldü (muhtemelen rotasyon): %s. Baştan okunuyor.\n", filePath)
-		file.Seek(0, io.SeekStart)
-		filePointers[filePath] = 0
-	} else {
-		// Mevcut pozisyondan okumaya devam et
-		file.Seek(filePointers[filePath], io.SeekStart)
+	if dev, ino, statOK := statInode(info); statOK && (dev != tf.dev || ino != tf.ino) {
+		// Aynı yolda farklı bir inode: rename-based rotasyon (logrotate "create"
+		// modu) sonrası dosya yeniden oluşturulmuş. Eski fd hâlâ taşınan inode'u
+		// işaret ediyor; onu boşaltıp kapatıyoruz ve yeni dosyayı baştan açıyoruz.
+		slog.Info("dosya rotasyonu tespit edildi (inode değişti)", "path", filePath)
+		if registry != nil {
+			registry.RotationEventsTotal.Inc()
+		}
+		drainAndClose(tf, filePath, func(line string) {
+			enqueueParsed(queue, tf, filePath, line, registry)
+		})
+		// tracker.delete, openTracked'in tracker.set ile üzerine yazacağı girdiyi
+		// önce kaldırır; aksi halde FilesWatched, RENAME/REMOVE üzerinden değil de
+		// burada (ör. PollingBackend'in aynı yolu düz bir Write olarak bildirdiği
+		// durumlarda) tespit edilen her rotasyonda bir fazla sayılır.
+		tracker.delete(filePath)
+		openTracked(tracker, filePath, tf.serviceLabel, tf.parser, tf.filters, nil, true)
+		tf, _ = tracker.get(filePath)
+	} else if info.Size() < tf.loadPos() {
+		// Copy-truncate rotasyonu: aynı inode, ama dosya küçülmüş.
+		slog.Info("dosya boyutu küçüldü (muhtemelen copy-truncate), baştan okunuyor", "path", filePath)
+		if registry != nil {
+			registry.RotationEventsTotal.Inc()
+		}
+		tf.storePos(0)
 	}
 
+	tf.f.Seek(tf.loadPos(), io.SeekStart)
+	scanner := bufio.NewScanner(tf.f)
 	for scanner.Scan() {
 		line := scanner.Text()
-		entry := parseLogLine(filePath, line) // Log satırını ayrıştır
-		sendToProcessor(entry)                // İşleyiciye gönder (şu an konsola yazıyor)
+		enqueueParsed(queue, tf, filePath, line, registry)
 	}
 
-	// Okuma bittikten sonra dosyanın yeni pozisyonunu kaydet
-	newPosition, err := file.Seek(0, io.SeekCurrent)
+	newPosition, err := tf.f.Seek(0, io.SeekCurrent)
 	if err != nil {
-		log.Printf("Dosya pozisyonu alınamadı '%s': %v", filePath, err)
+		slog.Warn("dosya pozisyonu alınamadı", "path", filePath, "error", err)
 		return
 	}
-	filePointers[filePath] = newPosition
+	tf.storePos(newPosition)
 
 	if err := scanner.Err(); err != nil {
-		log.Printf("Dosya okuma hatası '%s': %v", filePath, err)
+		slog.Warn("dosya okuma hatası", "path", filePath, "error", err)
 	}
 }
 
-// parseLogLine, basit bir log satırını LogEntry yapısına dönüştürür.
-// Burası, gerçek log formatınıza göre özelleştirilmelidir.
-func parseLogLine(filePath, line string) LogEntry {
-	serviceName := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
-	return LogEntry{
-		Timestamp: time.Now(), // Basitlik için şu anki zaman
-		Service:   serviceName,
-		Message:   line,
-	}
-}
+// enqueueParsed, tek bir ham satırı tf.parser ile ayrıştırır, Service alanını
+// ServiceLabel şablonuyla doldurur, tf.filters zincirini uygular ve zincir
+// girdiyi düşürmediği sürece kuyruğa ekler.
+func enqueueParsed(queue *sinkQueue, tf *trackedFile, filePath, line string, registry *metrics.Registry) {
+	entry := parseLogLine(tf.parser, filePath, tf.serviceLabel, line)
 
-// sendToProcessor, ayrıştırılmış log girdisini bir sonraki aşamaya (işleyiciye) gönderir.
-// Gerçek bir uygulamada burası Kafka, NATS, gRPC veya HTTP çağrısı olabilir.
-func sendToProcessor(entry LogEntry) {
-	// Şimdilik sadece konsola yazdırıyoruz.
-	// fmt.Printf("Log Gönderildi: [%s] [%s] %s\n", entry.Service, entry.Timestamp.Format(time.RFC3339), entry.Message)
+	if registry != nil {
+		registry.LinesReadTotal.WithLabelValues(entry.Service).Inc()
+		registry.BytesReadTotal.WithLabelValues(entry.Service).Add(float64(len(line)))
+	}
 
-	// JSON formatında yazdırma (daha gerçekçi bir çıktı için)
-	fmt.Printf("Log Gönderildi: %s\n", entry.String())
+	entry, keep := tf.filters.Apply(entry)
+	if !keep {
+		return
+	}
+	queue.Enqueue(entry)
 }
 
-// LogEntry için String() metodu (JSON formatında çıktı için)
-func (le LogEntry) String() string {
-	return fmt.Sprintf(`{"timestamp": "%s", "service": "%s", "message": "%s"}`,
-		le.Timestamp.Format(time.RFC3339Nano), le.Service, le.Message)
+// parseLogLine, parser'ın ayrıştıramadığı (ya da parser verilmemiş) durumlarda
+// bile her zaman bir Service ataması yapar; Timestamp/Message/Level/Fields ise
+// parser'dan gelir. serviceLabelTemplate boşsa eski
+// TrimSuffix(Base(path), Ext(path)) sezgisi kullanılır.
+func parseLogLine(parser parsers.Parser, filePath, serviceLabelTemplate, line string) model.LogEntry {
+	entry, err := parser.Parse(line)
+	if err != nil {
+		slog.Warn("log satırı ayrıştırılamadı", "path", filePath, "error", err)
+	}
+	entry.Service = fswatcher.ServiceLabel(serviceLabelTemplate, filePath)
+	return entry
 }
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// checkpointRecord, tek bir izlenen dosyanın son bilinen okuma pozisyonunu ve
+// (dev, ino) çiftini tutar; toplayıcı yeniden başladığında dosyanın hâlâ aynı
+// inode'u taşıyıp taşımadığını doğrulamak için kullanılır. Inode uyuşmazsa
+// (ör. dosya rotasyona uğramış), checkpoint yok sayılır.
+type checkpointRecord struct {
+	Dev uint64 `json:"dev"`
+	Ino uint64 `json:"ino"`
+	Pos int64  `json:"pos"`
+}
+
+// checkpointStore, izlenen dosyaların okuma ilerlemesini diske kalıcı hale
+// getirir; böylece toplayıcı yeniden başladığında "sona konumlan" sezgisine
+// geri dönüp kapalı kaldığı süre boyunca yazılmış satırları atlamaz.
+type checkpointStore struct {
+	path string
+
+	mu      sync.Mutex
+	records map[string]checkpointRecord
+
+	// flushMu, aynı geçici dosya yoluna (path + ".tmp") yazan ardışık flush()
+	// çağrılarının (periyodik goroutine ve kapanış yolu) birbirine karışmasını
+	// önler.
+	flushMu sync.Mutex
+}
+
+// loadCheckpointStore, verilen yoldaki checkpoint dosyasını okur. path boşsa
+// checkpoint devre dışıdır; dosya yoksa (ilk çalıştırma) boş bir store döner.
+func loadCheckpointStore(path string) (*checkpointStore, error) {
+	store := &checkpointStore{path: path, records: make(map[string]checkpointRecord)}
+	if path == "" {
+		return store, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &store.records); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// get, verilen yol için kayıtlı checkpoint'i (varsa) döner.
+func (s *checkpointStore) get(path string) (checkpointRecord, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, ok := s.records[path]
+	return rec, ok
+}
+
+// set, verilen yol için checkpoint'i günceller.
+func (s *checkpointStore) set(path string, rec checkpointRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[path] = rec
+}
+
+// snapshot, tracker'daki tüm izlenen dosyaların güncel (dev, ino, pos)
+// durumunu store'a yansıtır. flush'tan önce çağrılır.
+func (s *checkpointStore) snapshot(tracker *fileTracker) {
+	for _, path := range tracker.paths() {
+		tf, ok := tracker.get(path)
+		if !ok {
+			continue
+		}
+		s.set(path, checkpointRecord{Dev: tf.dev, Ino: tf.ino, Pos: tf.loadPos()})
+	}
+}
+
+// flush, store'u diske yazar. Yarım yazılmış bir dosyanın bir sonraki
+// başlangıçta okunmasını önlemek için önce aynı dizindeki geçici bir dosyaya
+// yazılır, sonra hedef yola atomik olarak (rename) taşınır.
+func (s *checkpointStore) flush() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	s.mu.Lock()
+	data, err := json.Marshal(s.records)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// runPeriodic, done kapanana kadar belirli aralıklarla tracker'ın güncel
+// durumunu yakalayıp diske yazar.
+func (s *checkpointStore) runPeriodic(tracker *fileTracker, interval time.Duration, done <-chan struct{}) {
+	if s.path == "" || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.snapshot(tracker)
+			if err := s.flush(); err != nil {
+				slog.Warn("checkpoint diske yazılamadı", "path", s.path, "error", err)
+			}
+		case <-done:
+			return
+		}
+	}
+}
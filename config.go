@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadConfig, verilen yoldaki yapılandırma dosyasını okur. Uzantı .yaml/.yml
+// ise YAML, aksi halde JSON olarak ayrıştırılır. Bu, sink seçimini (Kafka,
+// NATS, HTTP, stdout) main() içindeki hardcoded struct yerine bir dosyadan
+// yapılandırmayı mümkün kılar.
+func loadConfig(path string) (CollectorConfig, error) {
+	var config CollectorConfig
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("yapılandırma dosyası okunamadı '%s': %w", path, err)
+	}
+
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return config, fmt.Errorf("yapılandırma dosyası ayrıştırılamadı '%s': %w", path, err)
+		}
+	default:
+		if err := json.Unmarshal(data, &config); err != nil {
+			return config, fmt.Errorf("yapılandırma dosyası ayrıştırılamadı '%s': %w", path, err)
+		}
+	}
+
+	if err := compilePatterns(config.LogPatterns); err != nil {
+		return config, err
+	}
+	return config, nil
+}
@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+
+package main
+
+import "os"
+
+// statInode, (dev, inode) bilgisinin alınamadığı platformlarda ok=false döner;
+// çağıran taraf bu durumda yol bazlı (eski davranış) eşleşmeye geri düşer.
+func statInode(info os.FileInfo) (dev, ino uint64, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,20 @@
+//go:build linux || darwin
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// statInode, verilen os.FileInfo'nun altındaki (dev, inode) çiftini döner.
+// Bu bilgi, bir dosyanın yeniden adlandırma (logrotate "create" modu) ya da
+// yerinde kesme (copy-truncate) yoluyla değiştirilip değiştirilmediğini
+// ayırt etmek için kullanılır; dosya yolu aynı kalsa bile inode değişir.
+func statInode(info os.FileInfo) (dev, ino uint64, ok bool) {
+	sys, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint64(sys.Dev), uint64(sys.Ino), true
+}
@@ -0,0 +1,147 @@
+// Package filters, parser'dan çıkan LogEntry'lere sink'e gönderilmeden önce
+// uygulanan küçük bir dönüşüm/eleme zinciri tanımlar: regex ile satır eleme,
+// alan yeniden yazma ve servis adı değiştirme.
+package filters
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ca1ik/GO-Cloud/model"
+)
+
+// Filter, tek bir LogEntry üzerinde çalışır. keep=false dönerse girdi
+// zincirden düşürülür ve sink'e hiç gönderilmez.
+type Filter interface {
+	Apply(entry model.LogEntry) (out model.LogEntry, keep bool)
+}
+
+// Config, LogPattern başına uygulanacak tek bir filtrenin türünü ve
+// türe özgü ayarlarını tutar.
+type Config struct {
+	Type string `json:"type" yaml:"type"` // "drop", "fieldRewrite", "serviceRename"
+
+	Drop          DropConfig          `json:"drop" yaml:"drop"`
+	FieldRewrite  FieldRewriteConfig  `json:"fieldRewrite" yaml:"fieldRewrite"`
+	ServiceRename ServiceRenameConfig `json:"serviceRename" yaml:"serviceRename"`
+}
+
+// Chain, sırayla uygulanan bir Filter dizisidir.
+type Chain []Filter
+
+// Apply, zincirdeki her filtreyi sırayla uygular; herhangi biri keep=false
+// döndüğünde zincir durur ve girdi düşürülür.
+func (c Chain) Apply(entry model.LogEntry) (model.LogEntry, bool) {
+	for _, f := range c {
+		var keep bool
+		entry, keep = f.Apply(entry)
+		if !keep {
+			return entry, false
+		}
+	}
+	return entry, true
+}
+
+// Compile, Config dizisini çalıştırılabilir bir Chain'e derler.
+func Compile(configs []Config) (Chain, error) {
+	chain := make(Chain, 0, len(configs))
+	for _, cfg := range configs {
+		f, err := newFilter(cfg)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, f)
+	}
+	return chain, nil
+}
+
+func newFilter(cfg Config) (Filter, error) {
+	switch cfg.Type {
+	case "drop":
+		return newDropFilter(cfg.Drop)
+	case "fieldRewrite":
+		return newFieldRewriteFilter(cfg.FieldRewrite)
+	case "serviceRename":
+		return newServiceRenameFilter(cfg.ServiceRename)
+	default:
+		return nil, fmt.Errorf("filters: bilinmeyen filtre türü %q", cfg.Type)
+	}
+}
+
+// DropConfig, Message (ya da belirtilmişse bir Fields anahtarı) verilen
+// regex'le eşleşen girdileri eleyen bir filtre tanımlar.
+type DropConfig struct {
+	Field   string `json:"field" yaml:"field"` // Boşsa Message üzerinde çalışır
+	Pattern string `json:"pattern" yaml:"pattern"`
+}
+
+type dropFilter struct {
+	field string
+	re    *regexp.Regexp
+}
+
+func newDropFilter(cfg DropConfig) (*dropFilter, error) {
+	re, err := regexp.Compile(cfg.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("filters: drop deseni derlenemedi %q: %w", cfg.Pattern, err)
+	}
+	return &dropFilter{field: cfg.Field, re: re}, nil
+}
+
+func (f *dropFilter) Apply(entry model.LogEntry) (model.LogEntry, bool) {
+	value := entry.Message
+	if f.field != "" {
+		value = entry.Fields[f.field]
+	}
+	return entry, !f.re.MatchString(value)
+}
+
+// FieldRewriteConfig, bir Fields anahtarındaki (ya da Message'daki) değeri
+// basit bir string değiştirme ile yeniden yazan bir filtre tanımlar.
+type FieldRewriteConfig struct {
+	Field string `json:"field" yaml:"field"` // Boşsa Message üzerinde çalışır
+	From  string `json:"from" yaml:"from"`
+	To    string `json:"to" yaml:"to"`
+}
+
+type fieldRewriteFilter struct {
+	cfg FieldRewriteConfig
+}
+
+func newFieldRewriteFilter(cfg FieldRewriteConfig) (*fieldRewriteFilter, error) {
+	return &fieldRewriteFilter{cfg: cfg}, nil
+}
+
+func (f *fieldRewriteFilter) Apply(entry model.LogEntry) (model.LogEntry, bool) {
+	if f.cfg.Field == "" {
+		entry.Message = strings.ReplaceAll(entry.Message, f.cfg.From, f.cfg.To)
+		return entry, true
+	}
+	if v, ok := entry.Fields[f.cfg.Field]; ok {
+		entry.Fields[f.cfg.Field] = strings.ReplaceAll(v, f.cfg.From, f.cfg.To)
+	}
+	return entry, true
+}
+
+// ServiceRenameConfig, From ile eşleşen bir Service adını To ile değiştiren
+// bir filtre tanımlar.
+type ServiceRenameConfig struct {
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+}
+
+type serviceRenameFilter struct {
+	cfg ServiceRenameConfig
+}
+
+func newServiceRenameFilter(cfg ServiceRenameConfig) (*serviceRenameFilter, error) {
+	return &serviceRenameFilter{cfg: cfg}, nil
+}
+
+func (f *serviceRenameFilter) Apply(entry model.LogEntry) (model.LogEntry, bool) {
+	if entry.Service == f.cfg.From {
+		entry.Service = f.cfg.To
+	}
+	return entry, true
+}
@@ -0,0 +1,64 @@
+// Package fswatcher, işletim sistemine özgü dosya sistemi izleme mekanizmalarını
+// (Linux inotify, macOS kqueue) ve bunların güvenilir olmadığı durumlar (NFS, FUSE)
+// için saf Go ile yazılmış bir polling mekanizmasını tek bir arayüz arkasında soyutlar.
+package fswatcher
+
+import "time"
+
+// Op, bir Event'in hangi tür dosya sistemi değişikliğini temsil ettiğini belirtir.
+type Op uint32
+
+const (
+	Create Op = 1 << iota
+	Write
+	Remove
+	Rename
+	Chmod
+)
+
+// Event, izlenen bir yol üzerinde gözlemlenen tek bir dosya sistemi olayını temsil eder.
+type Event struct {
+	Path string
+	Op   Op
+}
+
+// Backend, bir dizin veya dosyayı izlemek için kullanılan alt seviye mekanizmayı soyutlar.
+// Uygulamalar inotify (Linux), kqueue (macOS) veya polling (her platform, NFS/FUSE dahil)
+// olabilir.
+type Backend interface {
+	// Add, verilen yolu (dosya ya da dizin) izleme listesine ekler.
+	Add(path string) error
+	// Remove, verilen yolu izleme listesinden çıkarır.
+	Remove(path string) error
+	// Events, gözlemlenen olayların yayınlandığı kanalı döner.
+	Events() <-chan Event
+	// Errors, izleme sırasında oluşan hataların yayınlandığı kanalı döner.
+	Errors() <-chan error
+	// Close, alttaki kaynakları serbest bırakır.
+	Close() error
+}
+
+// Options, bir Backend oluşturulurken kullanılan ayarları tutar.
+type Options struct {
+	// PollInterval, yalnızca PollingBackend tarafından kullanılır.
+	PollInterval time.Duration
+	// ForcePolling, platform native bir backend mevcut olsa bile polling
+	// kullanılmasını zorlar (ör. bilinen NFS/FUSE bağlama noktaları için).
+	ForcePolling bool
+}
+
+// New, platforma uygun en iyi Backend'i seçer. Native bir backend başlatılamazsa
+// (ör. inotify tanıtıcı limiti dolmuşsa) otomatik olarak PollingBackend'e düşer.
+func New(opts Options) (Backend, error) {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 2 * time.Second
+	}
+
+	if !opts.ForcePolling {
+		if b, err := newNativeBackend(); err == nil {
+			return b, nil
+		}
+	}
+
+	return NewPollingBackend(opts.PollInterval), nil
+}
@@ -0,0 +1,90 @@
+//go:build linux || darwin
+
+package fswatcher
+
+import "github.com/fsnotify/fsnotify"
+
+// fsnotifyBackend, github.com/fsnotify/fsnotify üzerinden inotify (Linux) veya
+// kqueue (macOS) kullanan bir Backend implementasyonudur.
+type fsnotifyBackend struct {
+	w      *fsnotify.Watcher
+	events chan Event
+	errors chan error
+	done   chan struct{}
+}
+
+func newFsnotifyBackend() (Backend, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &fsnotifyBackend{
+		w:      w,
+		events: make(chan Event),
+		errors: make(chan error),
+		done:   make(chan struct{}),
+	}
+	go b.run()
+	return b, nil
+}
+
+func (b *fsnotifyBackend) run() {
+	defer close(b.events)
+	defer close(b.errors)
+
+	for {
+		select {
+		case ev, ok := <-b.w.Events:
+			if !ok {
+				return
+			}
+			select {
+			case b.events <- Event{Path: ev.Name, Op: convertOp(ev.Op)}:
+			case <-b.done:
+				return
+			}
+		case err, ok := <-b.w.Errors:
+			if !ok {
+				return
+			}
+			select {
+			case b.errors <- err:
+			case <-b.done:
+				return
+			}
+		case <-b.done:
+			return
+		}
+	}
+}
+
+func convertOp(op fsnotify.Op) Op {
+	var out Op
+	if op&fsnotify.Create != 0 {
+		out |= Create
+	}
+	if op&fsnotify.Write != 0 {
+		out |= Write
+	}
+	if op&fsnotify.Remove != 0 {
+		out |= Remove
+	}
+	if op&fsnotify.Rename != 0 {
+		out |= Rename
+	}
+	if op&fsnotify.Chmod != 0 {
+		out |= Chmod
+	}
+	return out
+}
+
+func (b *fsnotifyBackend) Add(path string) error    { return b.w.Add(path) }
+func (b *fsnotifyBackend) Remove(path string) error { return b.w.Remove(path) }
+func (b *fsnotifyBackend) Events() <-chan Event     { return b.events }
+func (b *fsnotifyBackend) Errors() <-chan error     { return b.errors }
+
+func (b *fsnotifyBackend) Close() error {
+	close(b.done)
+	return b.w.Close()
+}
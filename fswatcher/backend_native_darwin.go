@@ -0,0 +1,8 @@
+//go:build darwin
+
+package fswatcher
+
+// newNativeBackend, macOS üzerinde kqueue tabanlı bir Backend döner.
+func newNativeBackend() (Backend, error) {
+	return newFsnotifyBackend()
+}
@@ -0,0 +1,8 @@
+//go:build linux
+
+package fswatcher
+
+// newNativeBackend, Linux üzerinde inotify tabanlı bir Backend döner.
+func newNativeBackend() (Backend, error) {
+	return newFsnotifyBackend()
+}
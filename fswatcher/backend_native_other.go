@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+
+package fswatcher
+
+import "errors"
+
+// newNativeBackend, desteklenmeyen platformlarda her zaman hata döner; New()
+// bunu görünce otomatik olarak PollingBackend'e düşer.
+func newNativeBackend() (Backend, error) {
+	return nil, errors.New("fswatcher: bu platform için native backend yok")
+}
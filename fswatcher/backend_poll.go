@@ -0,0 +1,219 @@
+package fswatcher
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// PollingBackend, inotify/kqueue olmadan, izlenen yolları periyodik olarak
+// os.Stat ile karşılaştırarak çalışan saf Go bir Backend'dir. NFS ve FUSE gibi
+// native olay bildirimi güvenilir olmayan dosya sistemlerinde kullanılır.
+//
+// Add bir dizinle çağrıldığında (ki scanAndWatchFiles'ın kullandığı tek yol
+// budur), dizinin kendi mtime/size'ı değil, içindeki dosyaların tek tek
+// durumu izlenir: her poll turunda dizin yeniden okunur ve önceki anlık
+// görüntüyle karşılaştırılır, böylece zaten izlenen bir dosyaya yapılan
+// yazımlar da (dizinin kendi mtime'ı değişmese bile) bir Write olayı üretir.
+// Add doğrudan bir dosya yoluyla da çağrılabilir; bu durumda yalnızca o yol
+// izlenir.
+type PollingBackend struct {
+	interval time.Duration
+	events   chan Event
+	errors   chan error
+	done     chan struct{}
+
+	mu    sync.Mutex
+	dirs  map[string]map[string]os.FileInfo // dizin yolu -> (içindeki dosya yolu -> son bilinen bilgi)
+	files map[string]os.FileInfo            // doğrudan eklenen (dizin olmayan) yollar
+}
+
+// NewPollingBackend, verilen aralıkla taranan bir PollingBackend oluşturur ve başlatır.
+func NewPollingBackend(interval time.Duration) *PollingBackend {
+	b := &PollingBackend{
+		interval: interval,
+		events:   make(chan Event),
+		errors:   make(chan error),
+		done:     make(chan struct{}),
+		dirs:     make(map[string]map[string]os.FileInfo),
+		files:    make(map[string]os.FileInfo),
+	}
+	go b.run()
+	return b
+}
+
+func (b *PollingBackend) Add(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		b.mu.Lock()
+		b.files[path] = info
+		b.mu.Unlock()
+		return nil
+	}
+
+	snapshot, err := readDirSnapshot(path)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	b.dirs[path] = snapshot
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *PollingBackend) Remove(path string) error {
+	b.mu.Lock()
+	delete(b.dirs, path)
+	delete(b.files, path)
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *PollingBackend) Events() <-chan Event { return b.events }
+func (b *PollingBackend) Errors() <-chan error { return b.errors }
+
+func (b *PollingBackend) Close() error {
+	close(b.done)
+	return nil
+}
+
+func (b *PollingBackend) run() {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+	defer close(b.events)
+	defer close(b.errors)
+
+	for {
+		select {
+		case <-ticker.C:
+			b.pollDirs()
+			b.pollFiles()
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// readDirSnapshot, verilen dizindeki düzenli dosyaların (alt dizinler hariç)
+// anlık (yol -> FileInfo) durumunu döner.
+func readDirSnapshot(dir string) (map[string]os.FileInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := make(map[string]os.FileInfo, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		snapshot[filepath.Join(dir, entry.Name())] = info
+	}
+	return snapshot, nil
+}
+
+// pollDirs, izlenen her dizini yeniden okuyup önceki anlık görüntüyle
+// karşılaştırır ve dizindeki her dosya için ayrı ayrı Create/Write/Remove
+// olayları üretir.
+func (b *PollingBackend) pollDirs() {
+	b.mu.Lock()
+	dirs := make([]string, 0, len(b.dirs))
+	for dir := range b.dirs {
+		dirs = append(dirs, dir)
+	}
+	b.mu.Unlock()
+
+	for _, dir := range dirs {
+		current, err := readDirSnapshot(dir)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				select {
+				case b.errors <- err:
+				case <-b.done:
+				}
+			}
+			continue
+		}
+
+		b.mu.Lock()
+		prev := b.dirs[dir]
+		b.dirs[dir] = current
+		b.mu.Unlock()
+
+		for path, info := range current {
+			prevInfo, existed := prev[path]
+			if !existed {
+				b.emit(Event{Path: path, Op: Create})
+				continue
+			}
+			if info.ModTime().After(prevInfo.ModTime()) || info.Size() != prevInfo.Size() {
+				b.emit(Event{Path: path, Op: Write})
+			}
+		}
+		for path := range prev {
+			if _, stillThere := current[path]; !stillThere {
+				b.emit(Event{Path: path, Op: Remove})
+			}
+		}
+	}
+}
+
+// pollFiles, doğrudan (dizin değil, dosya yolu olarak) eklenen girdileri eski
+// sezgiyle (tek bir os.Stat karşılaştırması) izler.
+func (b *PollingBackend) pollFiles() {
+	b.mu.Lock()
+	paths := make([]string, 0, len(b.files))
+	for p := range b.files {
+		paths = append(paths, p)
+	}
+	b.mu.Unlock()
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			b.mu.Lock()
+			prev, tracked := b.files[path]
+			delete(b.files, path)
+			b.mu.Unlock()
+			if tracked && prev != nil {
+				b.emit(Event{Path: path, Op: Remove})
+			}
+			continue
+		}
+		if err != nil {
+			select {
+			case b.errors <- err:
+			case <-b.done:
+			}
+			continue
+		}
+
+		b.mu.Lock()
+		prev, existed := b.files[path]
+		b.files[path] = info
+		b.mu.Unlock()
+
+		if !existed {
+			b.emit(Event{Path: path, Op: Create})
+			continue
+		}
+		if info.ModTime().After(prev.ModTime()) || info.Size() != prev.Size() {
+			b.emit(Event{Path: path, Op: Write})
+		}
+	}
+}
+
+func (b *PollingBackend) emit(ev Event) {
+	select {
+	case b.events <- ev:
+	case <-b.done:
+	}
+}
@@ -0,0 +1,135 @@
+package fswatcher
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Pattern, izlenecek tek bir glob deseninin derlenmiş halidir. `**` joker
+// karakterini destekler (ör. "./logs/**/*.log"), bu yüzden filepath.Glob
+// yerine kendi eşleştirme mantığımızı kullanırız.
+type Pattern struct {
+	Raw string
+
+	// StaticDir, desende joker karakterden önce gelen, değişmeyen dizin kısmıdır.
+	// Örn. "./logs/**/*.log" için StaticDir "./logs" olur. Backend bu dizini
+	// (ve `**` varsa tüm alt dizinlerini) izler; tek tek dosyaları değil.
+	StaticDir string
+
+	// Recursive, desende "**" bulunup bulunmadığını belirtir.
+	Recursive bool
+
+	// suffix, StaticDir'den sonra gelen ve dosya adlarıyla eşleştirilecek kısımdır.
+	suffix string
+}
+
+// CompilePattern, ham bir glob desenini ayrıştırıp statik dizin/joker karakter
+// ayrımını yapar ve başlangıçta doğrular.
+func CompilePattern(raw string) (*Pattern, error) {
+	clean := filepath.ToSlash(raw)
+	parts := strings.Split(clean, "/")
+
+	staticParts := make([]string, 0, len(parts))
+	i := 0
+	for ; i < len(parts); i++ {
+		if strings.ContainsAny(parts[i], "*?[") {
+			break
+		}
+		staticParts = append(staticParts, parts[i])
+	}
+
+	staticDir := strings.Join(staticParts, "/")
+	if staticDir == "" {
+		staticDir = "."
+	}
+
+	recursive := false
+	for _, p := range parts[i:] {
+		if p == "**" {
+			recursive = true
+			break
+		}
+	}
+
+	suffix := strings.Join(parts[i:], "/")
+	if suffix == "" {
+		return nil, fmt.Errorf("fswatcher: desen tamamen statik, joker karakter yok: %q", raw)
+	}
+
+	// Desenin kendisinin derlenebilir olduğunu doğrula (filepath.Match ** içeren
+	// segmentleri reddetmez çünkü biz ** segmentlerini eşleştirmeden önce ayıklıyoruz).
+	if _, err := filepath.Match(strings.ReplaceAll(suffix, "**", "*"), "probe"); err != nil {
+		return nil, fmt.Errorf("fswatcher: geçersiz desen %q: %w", raw, err)
+	}
+
+	return &Pattern{Raw: raw, StaticDir: staticDir, Recursive: recursive, suffix: suffix}, nil
+}
+
+// Match, verilen yolun bu desenle eşleşip eşleşmediğini belirtir. path,
+// filepath.Glob/WalkDir'den geldiği gibi StaticDir'i de içeren yoldur; Match
+// onu önce StaticDir'e göreli, slash ile ayrılmış biçime çevirir.
+func (p *Pattern) Match(path string) bool {
+	rel, err := filepath.Rel(p.StaticDir, path)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+
+	if !p.Recursive {
+		ok, _ := filepath.Match(p.suffix, rel)
+		return ok
+	}
+
+	// "**" herhangi bir sayıda dizin seviyesini (sıfır dahil) temsil eder, bu
+	// yüzden desen segmentlerini yoldaki segmentlere baştan sona eşleriz; "**"
+	// için her olası bölünme geri izlemeyle denenir.
+	patternSegs := strings.Split(p.suffix, "/")
+	pathSegs := strings.Split(rel, "/")
+	return matchSegments(patternSegs, pathSegs)
+}
+
+func matchSegments(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+	if pattern[0] == "**" {
+		if len(pattern) == 1 {
+			return true
+		}
+		for i := 0; i <= len(path); i++ {
+			if matchSegments(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		return false
+	}
+	ok, _ := filepath.Match(pattern[0], path[0])
+	if !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], path[1:])
+}
+
+// ServiceLabel, verilen dosya yolu için `{dir}-{basename}` gibi bir şablonu
+// çözümler. Şablon boşsa, eski `TrimSuffix(Base(path), Ext(path))` sezgisine
+// geri düşülür.
+func ServiceLabel(template, path string) string {
+	base := filepath.Base(path)
+	baseNoExt := strings.TrimSuffix(base, filepath.Ext(base))
+	dir := filepath.Base(filepath.Dir(path))
+
+	if template == "" {
+		return baseNoExt
+	}
+
+	r := strings.NewReplacer(
+		"{dir}", dir,
+		"{basename}", baseNoExt,
+		"{filename}", base,
+	)
+	return r.Replace(template)
+}
@@ -0,0 +1,37 @@
+package fswatcher
+
+import "testing"
+
+func TestPatternMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		path    string // Match'e, filepath.Glob/WalkDir'den geldiği gibi StaticDir'i de içeren tam yol olarak verilir
+		want    bool
+	}{
+		{"basit uzantı eşleşmesi", "./logs/*.log", "logs/app.log", true},
+		{"farklı uzantı eşleşmez", "./logs/*.log", "logs/app.txt", false},
+		{"recursive sıfır seviyede eşleşir", "./logs/**/*.log", "logs/app.log", true},
+		{"recursive bir alt dizinde eşleşir", "./logs/**/*.log", "logs/nginx/access.log", true},
+		{"recursive birden fazla alt dizinde eşleşir", "./logs/**/*.log", "logs/a/b/c/app.log", true},
+		{"recursive yanlış uzantı eşleşmez", "./logs/**/*.log", "logs/nginx/access.txt", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := CompilePattern(tt.pattern)
+			if err != nil {
+				t.Fatalf("CompilePattern(%q) hata döndü: %v", tt.pattern, err)
+			}
+			if got := p.Match(tt.path); got != tt.want {
+				t.Errorf("Match(%q) = %v, istenen %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompilePatternRejectsFullyStatic(t *testing.T) {
+	if _, err := CompilePattern("./logs/app.log"); err == nil {
+		t.Fatal("joker karakter içermeyen bir desen hata döndürmeliydi")
+	}
+}
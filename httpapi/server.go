@@ -0,0 +1,53 @@
+// Package httpapi, toplayıcının kendi teşhis uç noktalarını (/metrics,
+// /healthz, /debug/pprof) tek bir embedded HTTP sunucusu altında sunar.
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/ca1ik/GO-Cloud/metrics"
+)
+
+// Server, golog'un teşhis HTTP sunucusunu sarar.
+type Server struct {
+	httpServer *http.Server
+}
+
+// New, verilen adreste dinleyecek bir Server oluşturur. Sunucu Start
+// çağrılana kadar dinlemeye başlamaz.
+func New(addr string, registry *metrics.Registry) *Server {
+	mux := http.NewServeMux()
+
+	mux.Handle("/metrics", promhttp.HandlerFor(registry.Registerer, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	return &Server{httpServer: &http.Server{Addr: addr, Handler: mux}}
+}
+
+// Start, sunucuyu arka planda başlatır. ListenAndServe http.ErrServerClosed
+// dışında bir hatayla dönerse onErr çağrılır.
+func (s *Server) Start(onErr func(error)) {
+	go func() {
+		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			onErr(err)
+		}
+	}()
+}
+
+// Shutdown, sunucuyu kademeli olarak kapatır.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
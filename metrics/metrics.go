@@ -0,0 +1,79 @@
+// Package metrics, toplayıcının kendi iç durumunu (izlenen dosya sayısı,
+// okunan satır/bayt, ayrıştırma hataları, rotasyon olayları, sink gönderim
+// gecikmesi) Prometheus metrikleri olarak dışa verir.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Registry, golog'un kendi diagnostiklerini tutan Prometheus metriklerini
+// gruplar. httpapi paketi bunu /metrics altında dışa verir.
+type Registry struct {
+	Registerer *prometheus.Registry
+
+	FilesWatched        prometheus.Gauge
+	LinesReadTotal      *prometheus.CounterVec
+	BytesReadTotal      *prometheus.CounterVec
+	ParseErrorsTotal    *prometheus.CounterVec
+	RotationEventsTotal prometheus.Counter
+	SinkSendDuration    prometheus.Histogram
+	ScannerBacklogBytes prometheus.Gauge
+}
+
+// New, tüm metrikleri tanımlar ve verdikleri isimlerle kaydeder.
+func New() *Registry {
+	reg := prometheus.NewRegistry()
+
+	r := &Registry{
+		Registerer: reg,
+		FilesWatched: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "golog_files_watched",
+			Help: "Şu anda izlenen dosya sayısı.",
+		}),
+		LinesReadTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "golog_lines_read_total",
+			Help: "Servis başına okunan toplam satır sayısı.",
+		}, []string{"service"}),
+		BytesReadTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "golog_bytes_read_total",
+			Help: "Servis başına okunan toplam bayt sayısı.",
+		}, []string{"service"}),
+		ParseErrorsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "golog_parse_errors_total",
+			Help: "Parser başına ayrıştırma hatası sayısı.",
+		}, []string{"parser"}),
+		RotationEventsTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "golog_rotation_events_total",
+			Help: "Tespit edilen toplam log rotasyonu sayısı.",
+		}),
+		SinkSendDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "golog_sink_send_duration_seconds",
+			Help:    "Sink.Write çağrısının ne kadar sürdüğü.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		ScannerBacklogBytes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "golog_scanner_backlog_bytes",
+			Help: "Tüm izlenen dosyalar için (fileSize - filePointer) toplamı.",
+		}),
+	}
+
+	reg.MustRegister(
+		r.FilesWatched,
+		r.LinesReadTotal,
+		r.BytesReadTotal,
+		r.ParseErrorsTotal,
+		r.RotationEventsTotal,
+		r.SinkSendDuration,
+		r.ScannerBacklogBytes,
+	)
+
+	return r
+}
+
+// ObserveSinkSend, bir Sink.Write çağrısının süresini histogram'a kaydeder.
+func (r *Registry) ObserveSinkSend(d time.Duration) {
+	r.SinkSendDuration.Observe(d.Seconds())
+}
@@ -0,0 +1,28 @@
+// Package model, log toplayıcı boyunca (tailer, sink'ler, parser'lar) paylaşılan
+// veri tiplerini tanımlar.
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// LogEntry, okunacak her log satırını temsil eder
+type LogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Service   string    `json:"service"` // Hangi servisten geldiği (dosya adından tahmin edilebilir)
+	Message   string    `json:"message"`
+
+	Level  string            `json:"level,omitempty"`  // Grok/regex ile çıkarılan log seviyesi (INFO, ERROR, vb.)
+	Fields map[string]string `json:"fields,omitempty"` // Parser'ın çıkardığı ek alanlar (JSON/Grok/regex)
+	Raw    string            `json:"raw,omitempty"`    // Ayrıştırılmamış ham satır; hata ayıklama için saklanır
+}
+
+// String, LogEntry için JSON formatında bir çıktı üretir.
+func (le LogEntry) String() string {
+	data, err := json.Marshal(le)
+	if err != nil {
+		return le.Message
+	}
+	return string(data)
+}
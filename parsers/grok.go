@@ -0,0 +1,161 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/ca1ik/GO-Cloud/model"
+)
+
+// builtinGrokPatterns, yaygın log biçimleri için isimli RE2 alt desenlerini
+// tutar. Logstash'in grok-patterns dosyasının küçük, bağımlılıksız bir alt
+// kümesidir; `%{NAME}` ya da `%{NAME:field}` ile referans verilebilir.
+var builtinGrokPatterns = map[string]string{
+	"INT":               `[+-]?\d+`,
+	"WORD":              `\w+`,
+	"NOTSPACE":          `\S+`,
+	"GREEDYDATA":        `.*`,
+	"IPV4":              `\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}`,
+	"HOSTNAME":          `[a-zA-Z0-9.-]+`,
+	"LOGLEVEL":          `(?i:DEBUG|INFO|WARN|WARNING|ERROR|FATAL|TRACE)`,
+	"TIMESTAMP_ISO8601": `\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?`,
+	"HTTPDATE":          `\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}`,
+	"SYSLOGTIMESTAMP":   `\w{3} +\d{1,2} \d{2}:\d{2}:\d{2}`,
+
+	"COMMONAPACHELOG": `%{IPV4:clientip} \S+ \S+ \[%{HTTPDATE:timestamp}\] "%{WORD:verb} %{NOTSPACE:request} HTTP/%{NOTSPACE:httpversion}" %{INT:response} (?:-|\d+)`,
+	"NGINX":           `%{IPV4:clientip} - \S+ \[%{HTTPDATE:timestamp}\] "%{WORD:verb} %{NOTSPACE:request} HTTP/%{NOTSPACE:httpversion}" %{INT:response} %{INT:bytes}`,
+	"SYSLOG":          `%{SYSLOGTIMESTAMP:timestamp} %{HOSTNAME:host} %{WORD:program}(?:\[%{INT:pid}\])?: %{GREEDYDATA:message}`,
+}
+
+// grokRef, `%{NAME}` ya da `%{NAME:field}` biçimindeki referansları yakalar.
+var grokRef = regexp.MustCompile(`%\{(\w+)(?::(\w+))?\}`)
+
+// GrokConfig, GrokParser için kullanılacak deseni ve isteğe bağlı ek, kullanıcı
+// tanımlı desenleri tutar.
+type GrokConfig struct {
+	Pattern         string            `json:"pattern" yaml:"pattern"`
+	CustomPatterns  map[string]string `json:"customPatterns" yaml:"customPatterns"`
+	TimestampField  string            `json:"timestampField" yaml:"timestampField"`
+	TimestampFormat string            `json:"timestampFormat" yaml:"timestampFormat"`
+}
+
+// GrokParser, `%{TIMESTAMP_ISO8601:ts} %{LOGLEVEL:level} %{GREEDYDATA:msg}`
+// gibi isimli grok desenlerini RE2 regex'lerine derleyip her satıra uygular.
+type GrokParser struct {
+	re              *regexp.Regexp
+	timestampField  string
+	timestampFormat string
+}
+
+// NewGrokParser, verilen grok desenini derler.
+func NewGrokParser(cfg GrokConfig) (*GrokParser, error) {
+	if cfg.Pattern == "" {
+		return nil, fmt.Errorf("parsers: grok parser için pattern belirtilmeli")
+	}
+
+	patterns := builtinGrokPatterns
+	if len(cfg.CustomPatterns) > 0 {
+		patterns = make(map[string]string, len(builtinGrokPatterns)+len(cfg.CustomPatterns))
+		for k, v := range builtinGrokPatterns {
+			patterns[k] = v
+		}
+		for k, v := range cfg.CustomPatterns {
+			patterns[k] = v
+		}
+	}
+
+	expanded, err := expandGrokPattern(cfg.Pattern, patterns, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	re, err := regexp.Compile("^" + expanded + "$")
+	if err != nil {
+		return nil, fmt.Errorf("parsers: grok deseni derlenemedi %q: %w", cfg.Pattern, err)
+	}
+
+	timestampField := cfg.TimestampField
+	if timestampField == "" {
+		timestampField = "timestamp"
+	}
+	timestampFormat := cfg.TimestampFormat
+	if timestampFormat == "" {
+		timestampFormat = time.RFC3339
+	}
+
+	return &GrokParser{re: re, timestampField: timestampField, timestampFormat: timestampFormat}, nil
+}
+
+// expandGrokPattern, `%{NAME:field}` referanslarını, adlandırılmış yakalama
+// gruplarına (`(?P<field>...)`) sahip bir RE2 alt desenine özyinelemeli olarak
+// genişletir. depth, özyineleme derinliğidir; 10'u aşarsa (ör. döngüsel
+// tanımlarda) hata döner.
+func expandGrokPattern(pattern string, patterns map[string]string, depth int) (string, error) {
+	if depth > 10 {
+		return "", fmt.Errorf("parsers: grok deseni çok derin iç içe geçmiş (döngüsel tanım olabilir)")
+	}
+
+	var expandErr error
+	expanded := grokRef.ReplaceAllStringFunc(pattern, func(match string) string {
+		groups := grokRef.FindStringSubmatch(match)
+		name, field := groups[1], groups[2]
+
+		sub, ok := patterns[name]
+		if !ok {
+			expandErr = fmt.Errorf("parsers: bilinmeyen grok deseni %q", name)
+			return match
+		}
+
+		subExpanded, err := expandGrokPattern(sub, patterns, depth+1)
+		if err != nil {
+			expandErr = err
+			return match
+		}
+
+		if field != "" {
+			return fmt.Sprintf("(?P<%s>%s)", field, subExpanded)
+		}
+		return "(?:" + subExpanded + ")"
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return expanded, nil
+}
+
+func (p *GrokParser) Parse(raw string) (model.LogEntry, error) {
+	match := p.re.FindStringSubmatch(raw)
+	if match == nil {
+		return recordParseError("grok", raw), fmt.Errorf("parsers: satır grok deseniyle eşleşmedi")
+	}
+
+	entry := model.LogEntry{Raw: raw, Fields: make(map[string]string)}
+	for i, name := range p.re.SubexpNames() {
+		if i == 0 || name == "" {
+			continue
+		}
+		value := match[i]
+		switch name {
+		case "message", "msg":
+			entry.Message = value
+		case "level":
+			entry.Level = value
+		case p.timestampField:
+			entry.Fields[name] = value
+		default:
+			entry.Fields[name] = value
+		}
+	}
+
+	entry.Timestamp = time.Now()
+	if ts, ok := entry.Fields[p.timestampField]; ok {
+		if parsed, err := time.Parse(p.timestampFormat, ts); err == nil {
+			entry.Timestamp = parsed
+		} else {
+			atomicAddParseError("grok")
+		}
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,109 @@
+// Package parsers, ham log satırlarını model.LogEntry'ye dönüştüren pluggable
+// ayrıştırıcıları tanımlar: yapılandırılmış JSON logları için JSONParser,
+// isimli desenlerle eşleşen metin logları için GrokParser ve tek seferlik
+// yakalamalar için RegexParser.
+package parsers
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ca1ik/GO-Cloud/model"
+)
+
+// parseErrorCounts, her parser türü ("json", "grok", "regex") için o türün
+// bir satırı ayrıştıramadığı (ve time.Now()/ham satıra geri düştüğü) toplam
+// sayıyı ayrı ayrı tutar. metrics paketi bunu, `parser` etiketiyle birlikte
+// golog_parse_errors_total olarak dışa verir. Map başlangıçta doldurulur ve
+// bir daha yazılmaz; yalnızca işaret ettiği *uint64 değerler atomic olarak
+// güncellenir, bu yüzden eşzamanlı okuma için ayrı bir kilit gerekmez.
+var parseErrorCounts = map[string]*uint64{
+	"json":  new(uint64),
+	"grok":  new(uint64),
+	"regex": new(uint64),
+}
+
+// ParseErrorKinds, parseErrorCounts'ta izlenen parser türlerini döner.
+// reportParseErrors bunları gezip her biri için ayrı bir Prometheus etiketi
+// raporlar.
+func ParseErrorKinds() []string {
+	kinds := make([]string, 0, len(parseErrorCounts))
+	for kind := range parseErrorCounts {
+		kinds = append(kinds, kind)
+	}
+	return kinds
+}
+
+// ParseErrorCount, verilen parser türü için o ana kadar biriken hata sayısını
+// döner. Bilinmeyen bir tür için 0 döner.
+func ParseErrorCount(kind string) uint64 {
+	counter, ok := parseErrorCounts[kind]
+	if !ok {
+		return 0
+	}
+	return atomic.LoadUint64(counter)
+}
+
+// Parser, bir ham log satırını model.LogEntry'ye dönüştüren her
+// implementasyonun uygulaması gereken arayüzdür.
+type Parser interface {
+	Parse(raw string) (model.LogEntry, error)
+}
+
+// Config, LogPattern başına seçilen parser türünü ve türe özgü ayarları tutar.
+type Config struct {
+	Type string `json:"type" yaml:"type"` // "", "raw", "json", "grok", "regex"
+
+	JSON  JSONConfig  `json:"json" yaml:"json"`
+	Grok  GrokConfig  `json:"grok" yaml:"grok"`
+	Regex RegexConfig `json:"regex" yaml:"regex"`
+}
+
+// New, Config.Type alanına göre uygun Parser implementasyonunu oluşturur.
+// Type boş ya da "raw" ise, eski davranışı koruyan RawParser döner.
+func New(cfg Config) (Parser, error) {
+	switch cfg.Type {
+	case "", "raw":
+		return RawParser{}, nil
+	case "json":
+		return NewJSONParser(cfg.JSON), nil
+	case "grok":
+		return NewGrokParser(cfg.Grok)
+	case "regex":
+		return NewRegexParser(cfg.Regex)
+	default:
+		return nil, fmt.Errorf("parsers: bilinmeyen parser türü %q", cfg.Type)
+	}
+}
+
+// RawParser, hiçbir ayrıştırma yapmadan ham satırı Message alanına koyar ve
+// Timestamp için time.Now() kullanır. Önceki sürümdeki hardcoded davranışın
+// yerini alır; yapılandırılmamış (plain-text, özel format) loglar için varsayılandır.
+type RawParser struct{}
+
+func (RawParser) Parse(raw string) (model.LogEntry, error) {
+	return model.LogEntry{Timestamp: time.Now(), Message: raw, Raw: raw}, nil
+}
+
+// recordParseError, verilen parser türü için hata sayaçını artırır ve ham
+// satırı Message/Raw alanlarına koyan bir LogEntry döner. Timestamp, ayrıştırılan
+// bir değer olmadığından time.Now()'a düşer — RawParser'ın başarı yolundaki
+// sezgiyle aynı, ayrıştırma başarısız olsa bile girdinin zaman damgasız
+// kalmaması için. Her parser, kendi ayrıştırması başarısız olduğunda kendi
+// türünü (ör. "json") vererek bunu çağırmalıdır.
+func recordParseError(kind, raw string) model.LogEntry {
+	atomicAddParseError(kind)
+	return model.LogEntry{Timestamp: time.Now(), Message: raw, Raw: raw}
+}
+
+// atomicAddParseError, verilen parser türünün sayaçını bir artırır. Bir
+// parser kısmen başarılı olup (ör. mesajı ayrıştırıp) yalnızca zaman
+// damgasını çözemediğinde de bu fonksiyon çağrılır.
+func atomicAddParseError(kind string) {
+	counter, ok := parseErrorCounts[kind]
+	if !ok {
+		return
+	}
+	atomic.AddUint64(counter, 1)
+}
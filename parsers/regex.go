@@ -0,0 +1,80 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/ca1ik/GO-Cloud/model"
+)
+
+// RegexConfig, RegexParser için kullanılacak ham Go regex'ini (isimli yakalama
+// gruplarıyla, ör. `(?P<level>\w+)`) tutar. GrokParser'ın aksine herhangi bir
+// desen genişletmesi yapılmaz; tek seferlik özel yakalamalar içindir.
+type RegexConfig struct {
+	Pattern         string `json:"pattern" yaml:"pattern"`
+	TimestampField  string `json:"timestampField" yaml:"timestampField"`
+	TimestampFormat string `json:"timestampFormat" yaml:"timestampFormat"`
+}
+
+// RegexParser, verilen regex'i her satıra uygular ve isimli yakalama
+// gruplarını LogEntry.Fields'e (ya da bilinen isimler için Message/Level'a) yazar.
+type RegexParser struct {
+	re              *regexp.Regexp
+	timestampField  string
+	timestampFormat string
+}
+
+// NewRegexParser, verilen deseni derler.
+func NewRegexParser(cfg RegexConfig) (*RegexParser, error) {
+	if cfg.Pattern == "" {
+		return nil, fmt.Errorf("parsers: regex parser için pattern belirtilmeli")
+	}
+	re, err := regexp.Compile(cfg.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("parsers: regex derlenemedi %q: %w", cfg.Pattern, err)
+	}
+
+	timestampFormat := cfg.TimestampFormat
+	if timestampFormat == "" {
+		timestampFormat = time.RFC3339
+	}
+
+	return &RegexParser{re: re, timestampField: cfg.TimestampField, timestampFormat: timestampFormat}, nil
+}
+
+func (p *RegexParser) Parse(raw string) (model.LogEntry, error) {
+	match := p.re.FindStringSubmatch(raw)
+	if match == nil {
+		return recordParseError("regex", raw), fmt.Errorf("parsers: satır regex ile eşleşmedi")
+	}
+
+	entry := model.LogEntry{Raw: raw, Fields: make(map[string]string)}
+	for i, name := range p.re.SubexpNames() {
+		if i == 0 || name == "" {
+			continue
+		}
+		value := match[i]
+		switch name {
+		case "message", "msg":
+			entry.Message = value
+		case "level":
+			entry.Level = value
+		default:
+			entry.Fields[name] = value
+		}
+	}
+
+	entry.Timestamp = time.Now()
+	if p.timestampField != "" {
+		if ts, ok := entry.Fields[p.timestampField]; ok {
+			if parsed, err := time.Parse(p.timestampFormat, ts); err == nil {
+				entry.Timestamp = parsed
+			} else {
+				atomicAddParseError("regex")
+			}
+		}
+	}
+
+	return entry, nil
+}
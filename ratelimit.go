@@ -0,0 +1,82 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// leakyBucket, tek bir servis için saniyede izin verilen maksimum satır
+// sayısını sınırlayan klasik bir leaky-bucket hız sınırlayıcısıdır.
+type leakyBucket struct {
+	ratePerSec float64
+	capacity   float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newLeakyBucket(ratePerSec float64) *leakyBucket {
+	// Kapasite en az 1'dir; aksi halde saniyede 1'den düşük bir hızda kova
+	// hiçbir zaman tam bir token biriktiremez ve hiçbir satır geçmezdi.
+	capacity := ratePerSec
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &leakyBucket{
+		ratePerSec: ratePerSec,
+		capacity:   capacity,
+		tokens:     capacity,
+		last:       time.Now(),
+	}
+}
+
+// allow, bir satırın hemen gönderilip gönderilemeyeceğini belirtir; kovada
+// token kalmamışsa satır reddedilir ve çağıran taraf bunu WAL'a yazabilir.
+func (b *leakyBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+
+	b.tokens += elapsed * b.ratePerSec
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// rateLimiter, her servis için ayrı bir leakyBucket tutar, böylece gürültülü
+// bir servis diğerlerinin hızını etkilemez.
+type rateLimiter struct {
+	ratePerSec float64
+
+	mu      sync.Mutex
+	buckets map[string]*leakyBucket
+}
+
+func newRateLimiter(ratePerSec float64) *rateLimiter {
+	return &rateLimiter{ratePerSec: ratePerSec, buckets: make(map[string]*leakyBucket)}
+}
+
+func (r *rateLimiter) allow(service string) bool {
+	if r.ratePerSec <= 0 {
+		return true // Sınırsız
+	}
+
+	r.mu.Lock()
+	b, ok := r.buckets[service]
+	if !ok {
+		b = newLeakyBucket(r.ratePerSec)
+		r.buckets[service] = b
+	}
+	r.mu.Unlock()
+
+	return b.allow()
+}
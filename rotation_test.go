@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ca1ik/GO-Cloud/model"
+	"github.com/ca1ik/GO-Cloud/parsers"
+)
+
+// fakeSink, testlerde sinks.Sink yerine geçen, girdileri bellekte toplayan
+// basit bir sahte implementasyondur.
+type fakeSink struct {
+	mu      sync.Mutex
+	entries []model.LogEntry
+}
+
+func (s *fakeSink) Write(ctx context.Context, entries []model.LogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entries...)
+	return nil
+}
+
+func (s *fakeSink) Flush(ctx context.Context) error { return nil }
+func (s *fakeSink) Close() error                    { return nil }
+
+func (s *fakeSink) messages() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]string, len(s.entries))
+	for i, e := range s.entries {
+		out[i] = e.Message
+	}
+	return out
+}
+
+func newTestQueue(t *testing.T) (*sinkQueue, *fakeSink) {
+	t.Helper()
+	sink := &fakeSink{}
+	q, err := newSinkQueue(sink, SinkQueueConfig{Depth: 16}, nil)
+	if err != nil {
+		t.Fatalf("newSinkQueue: %v", err)
+	}
+	t.Cleanup(func() { q.Close() })
+	return q, sink
+}
+
+func waitForMessages(t *testing.T, sink *fakeSink, want int) []string {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if msgs := sink.messages(); len(msgs) >= want {
+			return msgs
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("zaman aşımı: %d mesaj bekleniyordu, alınan: %v", want, sink.messages())
+	return nil
+}
+
+// runProcessFileChanges, processFileChanges'i senkron olarak (ayrı bir
+// goroutine başlatmadan) çalıştırır; fonksiyon kendi içinde wg.Done() çağırdığı
+// için testte önceden wg.Add(1) yapılır.
+func runProcessFileChanges(filePath string, tracker *fileTracker, queue *sinkQueue) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	processFileChanges(context.Background(), filePath, tracker, queue, &wg, nil)
+	wg.Wait()
+}
+
+// processFileChanges rename-based rotasyonda (inode değişti) yeni dosyayı
+// offset 0'dan okumalı, EOF'tan değil; aksi halde rotasyon anında zaten
+// yazılmış satırlar sessizce kaybolur.
+func TestProcessFileChangesRenameRotationReadsNewFileFromStart(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	if err := os.WriteFile(path, []byte("old-1\n"), 0o644); err != nil {
+		t.Fatalf("yazılamadı: %v", err)
+	}
+
+	tracker := newFileTracker(nil)
+	queue, sink := newTestQueue(t)
+	openTracked(tracker, path, "", parsers.RawParser{}, nil, nil, false)
+
+	tfOld, ok := tracker.get(path)
+	if !ok {
+		t.Fatal("dosya tracker'da bulunamadı")
+	}
+	if got := tfOld.loadPos(); got != int64(len("old-1\n")) {
+		t.Fatalf("başlangıç pozisyonu = %d, istenen %d (EOF)", got, len("old-1\n"))
+	}
+
+	// logrotate "create" modunu simüle et: eski dosya başka bir yere taşınır,
+	// aynı yolda farklı bir inode'a sahip yeni bir dosya oluşturulur ve bu
+	// yeni dosyaya, fark edilmeden önce zaten veri yazılmış olur.
+	if err := os.Rename(path, filepath.Join(dir, "app.log.1")); err != nil {
+		t.Fatalf("yeniden adlandırılamadı: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("new-1\nnew-2\n"), 0o644); err != nil {
+		t.Fatalf("yeni dosya yazılamadı: %v", err)
+	}
+
+	runProcessFileChanges(path, tracker, queue)
+
+	tfNew, ok := tracker.get(path)
+	if !ok {
+		t.Fatal("rotasyon sonrası dosya tracker'da bulunamadı")
+	}
+	if tfNew == tfOld {
+		t.Fatal("rotasyon sonrası trackedFile yeniden açılmamış")
+	}
+	if got := tfNew.loadPos(); got != int64(len("new-1\nnew-2\n")) {
+		t.Errorf("rotasyon sonrası pozisyon = %d, istenen %d (tüm yeni içerik okunmuş olmalı)", got, len("new-1\nnew-2\n"))
+	}
+
+	got := waitForMessages(t, sink, 2)
+	if want := []string{"new-1", "new-2"}; !equalStrings(got, want) {
+		t.Errorf("yeni dosyadan okunan satırlar = %v, istenen %v (offset 0'dan okunmalıydı)", got, want)
+	}
+}
+
+// processFileChanges copy-truncate rotasyonunda (aynı inode, küçülen boyut)
+// dosyayı baştan okumalı.
+func TestProcessFileChangesCopyTruncateResetsToStart(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	if err := os.WriteFile(path, []byte("0123456789\n"), 0o644); err != nil {
+		t.Fatalf("yazılamadı: %v", err)
+	}
+
+	tracker := newFileTracker(nil)
+	queue, sink := newTestQueue(t)
+	openTracked(tracker, path, "", parsers.RawParser{}, nil, nil, false)
+
+	// copy-truncate: aynı dosya tanıtıcısı (inode), ama içerik daha kısa bir
+	// satırla değiştirilmiş.
+	if err := os.Truncate(path, 0); err != nil {
+		t.Fatalf("truncate edilemedi: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("short\n"), 0o644); err != nil {
+		t.Fatalf("yazılamadı: %v", err)
+	}
+
+	runProcessFileChanges(path, tracker, queue)
+
+	got := waitForMessages(t, sink, 1)
+	if want := []string{"short"}; !equalStrings(got, want) {
+		t.Errorf("copy-truncate sonrası okunan satırlar = %v, istenen %v", got, want)
+	}
+}
+
+// handleRemoval, aynı yol için hâlâ devam eden (processLock'u tutan) bir
+// processFileChanges çağrısıyla aynı *os.File üzerinde eşzamanlı Seek/Scan/
+// Close yapmamalı; processLock serbest kalana kadar beklemeli.
+func TestHandleRemovalWaitsForInFlightProcessing(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(path, []byte("line1\n"), 0o644); err != nil {
+		t.Fatalf("yazılamadı: %v", err)
+	}
+
+	tracker := newFileTracker(nil)
+	queue, _ := newTestQueue(t)
+	openTracked(tracker, path, "", parsers.RawParser{}, nil, nil, false)
+
+	// processFileChanges'in dosyayı işlerken kilidi tuttuğunu simüle et.
+	lock := tracker.processLock(path)
+	lock.Lock()
+
+	done := make(chan struct{})
+	go func() {
+		handleRemoval(path, tracker, queue, nil)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("handleRemoval, processLock tutulurken tamamlandı; aynı fd üzerinde yarış mümkün")
+	case <-time.After(50 * time.Millisecond):
+		// Beklenen: kilit serbest kalana kadar bloke.
+	}
+
+	lock.Unlock()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("kilit bırakıldıktan sonra handleRemoval tamamlanmadı")
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ca1ik/GO-Cloud/metrics"
+	"github.com/ca1ik/GO-Cloud/model"
+	"github.com/ca1ik/GO-Cloud/sinks"
+)
+
+// SinkQueueConfig, tailer ile sink arasındaki sınırlı kuyruğun davranışını belirler.
+type SinkQueueConfig struct {
+	// Depth, bellekte tutulacak maksimum girdi sayısıdır. Kuyruk dolduğunda
+	// yeni girdiler WAL'a yazılır; tailer asla scanner.Scan() üzerinde bloke olmaz.
+	Depth int
+
+	// RateLimitPerService, servis başına saniyede izin verilen maksimum satır
+	// sayısıdır (leaky-bucket). Sıfır ya da negatifse sınırsızdır.
+	RateLimitPerService float64
+
+	// WALPath, kuyruk dolduğunda ya da sink başarısız olduğunda girdilerin
+	// spill edileceği dosyanın yoludur. Boşsa WAL devre dışıdır ve spill
+	// edilen girdiler diske yazılmadan atılır.
+	WALPath string
+
+	// DrainInterval, WAL'daki birikmiş girdilerin yeniden denenme sıklığıdır.
+	DrainInterval time.Duration
+}
+
+// sinkQueue, processFileChanges tarafından üretilen girdileri sınırlı bir
+// kanal üzerinden sink'e iletir. Kanal dolduğunda ya da sink.Write kalıcı
+// olarak başarısız olduğunda girdiler diske yazılır (WAL) ve bir sonraki
+// drain turunda yeniden denenir; böylece bir patlama ya da downstream kesinti
+// tailer'ı bloke etmez ya da satır kaybına yol açmaz. WALPath boşsa bu
+// girdiler diske yazılmaz, atılır.
+type sinkQueue struct {
+	sink    sinks.Sink
+	limiter *rateLimiter
+	wal     *diskSpillWAL
+	metrics *metrics.Registry
+
+	entries chan model.LogEntry
+	done    chan struct{}
+}
+
+// newSinkQueue, verilen sink ve yapılandırmayla bir sinkQueue oluşturur ve
+// arka plan tüketici goroutine'ini başlatır.
+func newSinkQueue(sink sinks.Sink, cfg SinkQueueConfig, reg *metrics.Registry) (*sinkQueue, error) {
+	if cfg.Depth <= 0 {
+		cfg.Depth = 1000
+	}
+	if cfg.DrainInterval <= 0 {
+		cfg.DrainInterval = 5 * time.Second
+	}
+
+	wal, err := newDiskSpillWAL(cfg.WALPath)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &sinkQueue{
+		sink:    sink,
+		limiter: newRateLimiter(cfg.RateLimitPerService),
+		wal:     wal,
+		metrics: reg,
+		entries: make(chan model.LogEntry, cfg.Depth),
+		done:    make(chan struct{}),
+	}
+	go q.consume()
+	go q.drainLoop(cfg.DrainInterval)
+	return q, nil
+}
+
+// Enqueue, verilen girdiyi kuyruğa ekler. Hız sınırına takılırsa ya da kuyruk
+// doluysa (downstream bir patlamayı ya da kesintiyi karşılayamıyorsa), girdi
+// WAL'a yazılır (WAL devre dışıysa atılır) ve Enqueue yine de bloke olmadan döner.
+func (q *sinkQueue) Enqueue(entry model.LogEntry) {
+	if !q.limiter.allow(entry.Service) {
+		q.spill(entry)
+		return
+	}
+
+	select {
+	case q.entries <- entry:
+	default:
+		q.spill(entry)
+	}
+}
+
+func (q *sinkQueue) spill(entry model.LogEntry) {
+	if err := q.wal.append(entry); err != nil {
+		slog.Error("sinkQueue: girdi WAL'a yazılamadı, siliniyor", "error", err)
+	}
+}
+
+func (q *sinkQueue) consume() {
+	for {
+		select {
+		case entry, ok := <-q.entries:
+			if !ok {
+				return
+			}
+			start := time.Now()
+			err := q.sink.Write(context.Background(), []model.LogEntry{entry})
+			if q.metrics != nil {
+				q.metrics.ObserveSinkSend(time.Since(start))
+			}
+			if err != nil {
+				slog.Error("sinkQueue: sink.Write başarısız, WAL'a yazılıyor", "error", err)
+				q.spill(entry)
+			}
+		case <-q.done:
+			return
+		}
+	}
+}
+
+// drainLoop, WAL'daki birikmiş girdileri periyodik olarak sink'e yeniden
+// göndermeyi dener.
+func (q *sinkQueue) drainLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := q.wal.drain(func(entry model.LogEntry) bool {
+				return q.sink.Write(context.Background(), []model.LogEntry{entry}) == nil
+			}); err != nil {
+				slog.Error("sinkQueue: WAL boşaltılamadı", "error", err)
+			}
+		case <-q.done:
+			return
+		}
+	}
+}
+
+func (q *sinkQueue) Close() error {
+	close(q.done)
+	if err := q.sink.Flush(context.Background()); err != nil {
+		slog.Error("sinkQueue: kapanışta flush başarısız", "error", err)
+	}
+	if err := q.wal.close(); err != nil {
+		slog.Error("sinkQueue: WAL kapatılamadı", "error", err)
+	}
+	return q.sink.Close()
+}
@@ -0,0 +1,123 @@
+package sinks
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ca1ik/GO-Cloud/model"
+)
+
+// HTTPConfig, HTTPSink'in toplu POST isteklerini göndereceği uç noktayı ve
+// kimlik bilgilerini tanımlar.
+type HTTPConfig struct {
+	URL            string            `json:"url" yaml:"url"`
+	Headers        map[string]string `json:"headers" yaml:"headers"`
+	RequestTimeout time.Duration     `json:"requestTimeout" yaml:"requestTimeout"`
+}
+
+// HTTPSink, log girdilerini gzip ile sıkıştırılmış toplu POST istekleri olarak
+// bir HTTP uç noktasına gönderir. Girdiler BatchSize'a ulaşana ya da Flush
+// (Close dahil) çağrılana kadar bellekte biriktirilir. interval alanı saklanır
+// ama kullanılmaz: FlushInterval dolduğunda zamanlayıcıyla flush yapılmaz.
+type HTTPSink struct {
+	cfg    HTTPConfig
+	client *http.Client
+
+	batchSize int
+	interval  time.Duration
+
+	mu      sync.Mutex
+	pending []model.LogEntry
+}
+
+// NewHTTPSink, verilen yapılandırma, toplu gönderim boyutu ve flush aralığıyla
+// bir HTTPSink oluşturur.
+func NewHTTPSink(cfg HTTPConfig, batchSize int, interval time.Duration) (*HTTPSink, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("sinks: http sink için url belirtilmeli")
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = 10 * time.Second
+	}
+
+	return &HTTPSink{
+		cfg:       cfg,
+		client:    &http.Client{Timeout: cfg.RequestTimeout},
+		batchSize: batchSize,
+		interval:  interval,
+	}, nil
+}
+
+func (s *HTTPSink) Write(ctx context.Context, entries []model.LogEntry) error {
+	s.mu.Lock()
+	s.pending = append(s.pending, entries...)
+	shouldFlush := len(s.pending) >= s.batchSize
+	s.mu.Unlock()
+
+	if shouldFlush {
+		return s.Flush(ctx)
+	}
+	return nil
+}
+
+func (s *HTTPSink) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	batch := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if err := s.send(ctx, batch); err != nil {
+		// Gönderim başarısız oldu; bu turda biriktirilen batch'i kaybetmemek için
+		// geri koy. Flush sırasında eş zamanlı Write çağrıları s.pending'e yeni
+		// girdiler eklemiş olabileceğinden, bunların önüne ekleriz ki sıra korunsun.
+		s.mu.Lock()
+		s.pending = append(batch, s.pending...)
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (s *HTTPSink) send(ctx context.Context, batch []model.LogEntry) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(batch); err != nil {
+		return fmt.Errorf("sinks: toplu gönderim serileştirilemedi: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("sinks: gzip akışı kapatılamadı: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, &buf)
+	if err != nil {
+		return fmt.Errorf("sinks: istek oluşturulamadı: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	for k, v := range s.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sinks: http isteği başarısız: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sinks: http sink beklenmeyen durum kodu döndürdü: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *HTTPSink) Close() error { return s.Flush(context.Background()) }
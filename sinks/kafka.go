@@ -0,0 +1,65 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+
+	"github.com/ca1ik/GO-Cloud/model"
+)
+
+const defaultKafkaBatchTimeout = 100 * time.Millisecond
+
+// KafkaConfig, KafkaSink'in bağlanacağı broker'ları ve hedef topic'i tanımlar.
+type KafkaConfig struct {
+	Brokers []string `json:"brokers" yaml:"brokers"`
+	Topic   string   `json:"topic" yaml:"topic"`
+}
+
+// KafkaSink, ayrıştırılmış log girdilerini segmentio/kafka-go ile Kafka'ya yazar.
+// Her LogEntry, Service alanı mesaj anahtarı olacak şekilde JSON'a serileştirilir
+// (böylece aynı servise ait mesajlar aynı partition'a gider).
+type KafkaSink struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaSink, verilen yapılandırmayla bağlı bir KafkaSink oluşturur.
+func NewKafkaSink(cfg KafkaConfig) (*KafkaSink, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("sinks: kafka için en az bir broker gerekli")
+	}
+	if cfg.Topic == "" {
+		return nil, fmt.Errorf("sinks: kafka için topic belirtilmeli")
+	}
+
+	return &KafkaSink{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(cfg.Brokers...),
+			Topic:        cfg.Topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireOne,
+			BatchTimeout: defaultKafkaBatchTimeout,
+		},
+	}, nil
+}
+
+func (s *KafkaSink) Write(ctx context.Context, entries []model.LogEntry) error {
+	messages := make([]kafka.Message, 0, len(entries))
+	for _, entry := range entries {
+		payload, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("sinks: log girdisi serileştirilemedi: %w", err)
+		}
+		messages = append(messages, kafka.Message{
+			Key:   []byte(entry.Service),
+			Value: payload,
+		})
+	}
+	return s.writer.WriteMessages(ctx, messages...)
+}
+
+func (s *KafkaSink) Flush(_ context.Context) error { return nil }
+func (s *KafkaSink) Close() error                  { return s.writer.Close() }
@@ -0,0 +1,76 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/ca1ik/GO-Cloud/model"
+)
+
+// NATSConfig, NATSSink'in bağlanacağı sunucuyu ve JetStream akışını/konusunu tanımlar.
+type NATSConfig struct {
+	URL     string `json:"url" yaml:"url"`
+	Subject string `json:"subject" yaml:"subject"`
+	Stream  string `json:"stream" yaml:"stream"`
+}
+
+// NATSSink, log girdilerini NATS JetStream üzerinden yayınlar ve her mesaj
+// için ack bekleyerek en az bir kez teslimat garantisi sağlar.
+type NATSSink struct {
+	nc      *nats.Conn
+	js      nats.JetStreamContext
+	subject string
+}
+
+// NewNATSSink, verilen yapılandırmayla bağlı bir NATSSink oluşturur; gerekli
+// JetStream akışı yoksa oluşturur.
+func NewNATSSink(cfg NATSConfig) (*NATSSink, error) {
+	if cfg.Subject == "" {
+		return nil, fmt.Errorf("sinks: nats için subject belirtilmeli")
+	}
+
+	nc, err := nats.Connect(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("sinks: nats'a bağlanılamadı: %w", err)
+	}
+
+	js, err := nc.JetStream()
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("sinks: jetstream context alınamadı: %w", err)
+	}
+
+	if cfg.Stream != "" {
+		if _, err := js.StreamInfo(cfg.Stream); err != nil {
+			if _, err := js.AddStream(&nats.StreamConfig{Name: cfg.Stream, Subjects: []string{cfg.Subject}}); err != nil {
+				nc.Close()
+				return nil, fmt.Errorf("sinks: jetstream akışı oluşturulamadı: %w", err)
+			}
+		}
+	}
+
+	return &NATSSink{nc: nc, js: js, subject: cfg.Subject}, nil
+}
+
+func (s *NATSSink) Write(ctx context.Context, entries []model.LogEntry) error {
+	for _, entry := range entries {
+		payload, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("sinks: log girdisi serileştirilemedi: %w", err)
+		}
+		if _, err := s.js.Publish(s.subject, payload, nats.Context(ctx)); err != nil {
+			return fmt.Errorf("sinks: jetstream'e yayınlanamadı: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *NATSSink) Flush(_ context.Context) error { return s.nc.Flush() }
+
+func (s *NATSSink) Close() error {
+	s.nc.Close()
+	return nil
+}
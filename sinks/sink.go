@@ -0,0 +1,61 @@
+// Package sinks, ayrıştırılmış log girdilerinin nihai varış noktalarını
+// (Kafka, NATS, HTTP toplu uç noktası, ya da geliştirme için stdout) tek bir
+// arayüz arkasında soyutlar.
+package sinks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ca1ik/GO-Cloud/model"
+)
+
+// Sink, ayrıştırılmış log girdilerini bir varış noktasına yazan her
+// implementasyonun uygulaması gereken arayüzdür.
+type Sink interface {
+	// Write, verilen girdi toplulunu varış noktasına gönderir. Sink kendi
+	// içinde batching yapıyorsa bu çağrı girdileri yalnızca kuyruğa alabilir;
+	// kalıcı teslimat garantisi için Flush kullanılmalıdır.
+	Write(ctx context.Context, entries []model.LogEntry) error
+	// Flush, bekleyen tüm girdilerin varış noktasına ulaşmasını sağlar.
+	Flush(ctx context.Context) error
+	// Close, sink'in altındaki bağlantıları/kaynakları serbest bırakır.
+	Close() error
+}
+
+// Config, CollectorConfig.Sink alanından çözümlenen sink seçimini ve her
+// sink türüne özgü ayarları tutar.
+type Config struct {
+	Type string `json:"type" yaml:"type"` // "stdout", "kafka", "nats", "http"
+
+	BatchSize     int           `json:"batchSize" yaml:"batchSize"`
+	FlushInterval time.Duration `json:"flushInterval" yaml:"flushInterval"`
+
+	Kafka KafkaConfig `json:"kafka" yaml:"kafka"`
+	NATS  NATSConfig  `json:"nats" yaml:"nats"`
+	HTTP  HTTPConfig  `json:"http" yaml:"http"`
+}
+
+// New, Config.Type alanına göre uygun Sink implementasyonunu oluşturur.
+func New(cfg Config) (Sink, error) {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = 2 * time.Second
+	}
+
+	switch cfg.Type {
+	case "", "stdout":
+		return NewStdoutSink(), nil
+	case "kafka":
+		return NewKafkaSink(cfg.Kafka)
+	case "nats":
+		return NewNATSSink(cfg.NATS)
+	case "http":
+		return NewHTTPSink(cfg.HTTP, cfg.BatchSize, cfg.FlushInterval)
+	default:
+		return nil, fmt.Errorf("sinks: bilinmeyen sink türü %q", cfg.Type)
+	}
+}
@@ -0,0 +1,26 @@
+package sinks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ca1ik/GO-Cloud/model"
+)
+
+// StdoutSink, girdileri doğrudan konsola yazar. Harici bir bağımlılık
+// gerektirmediği için yerel geliştirme ve testlerde varsayılan sink'tir;
+// eski hardcoded `sendToProcessor`/`fmt.Printf` davranışını korur.
+type StdoutSink struct{}
+
+// NewStdoutSink, yeni bir StdoutSink oluşturur.
+func NewStdoutSink() *StdoutSink { return &StdoutSink{} }
+
+func (s *StdoutSink) Write(_ context.Context, entries []model.LogEntry) error {
+	for _, entry := range entries {
+		fmt.Printf("Log Gönderildi: %s\n", entry.String())
+	}
+	return nil
+}
+
+func (s *StdoutSink) Flush(_ context.Context) error { return nil }
+func (s *StdoutSink) Close() error                  { return nil }
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ca1ik/GO-Cloud/filters"
+	"github.com/ca1ik/GO-Cloud/metrics"
+	"github.com/ca1ik/GO-Cloud/parsers"
+)
+
+// trackedFile, tek bir izlenen dosyanın açık dosya tanıtıcısını ve okuma
+// ilerlemesini tutar. (dev, ino) çifti, logrotate'in "create" modunda olduğu
+// gibi dosya yeniden adlandırıldığında aynı yoldaki yeni dosyayı eskisinden
+// ayırt etmek için kullanılır.
+type trackedFile struct {
+	f        *os.File
+	ino, dev uint64
+
+	// pos, processFileChanges tarafından güncellenirken checkpointStore.snapshot
+	// ve fileTracker.backlogBytes tarafından eşzamanlı olarak okunur; bu yüzden
+	// doğrudan değil loadPos/storePos üzerinden, atomic olarak erişilir.
+	pos int64
+
+	serviceLabel string
+	parser       parsers.Parser
+	filters      filters.Chain
+	rotatingAway bool // true ise bu fd eski inode'a ait, EOF'a kadar boşaltılıp kapatılacak
+}
+
+func (tf *trackedFile) loadPos() int64     { return atomic.LoadInt64(&tf.pos) }
+func (tf *trackedFile) storePos(pos int64) { atomic.StoreInt64(&tf.pos, pos) }
+
+// fileTracker, izlenen tüm dosyaların durumunu eşzamanlılığa karşı korunmuş
+// şekilde tutar. Polling goroutine'i ve olay goroutine'i aynı haritaya eriştiği
+// için bare map kullanmak yarış koşuluna yol açardı.
+type fileTracker struct {
+	mu      sync.Mutex
+	files   map[string]*trackedFile
+	metrics *metrics.Registry
+
+	procMu   sync.Mutex
+	procLock map[string]*sync.Mutex
+}
+
+func newFileTracker(reg *metrics.Registry) *fileTracker {
+	return &fileTracker{
+		files:    make(map[string]*trackedFile),
+		metrics:  reg,
+		procLock: make(map[string]*sync.Mutex),
+	}
+}
+
+// processLock, verilen yol için, o yola özgü, tembel oluşturulan bir
+// sync.Mutex döner. processFileChanges, aynı yol için üst üste gelen (ör.
+// hızlı ardışık Write olaylarının her biri ayrı bir goroutine başlattığından)
+// çağrıları bu kilitle serileştirir; aksi halde iki goroutine aynı *os.File
+// üzerinde eşzamanlı Seek/Scan yapıp okuma pozisyonunda yarışa girer ve satır
+// akışını karıştırabilir/tekrarlayabilir. Kilit yolun kendisine bağlıdır, o
+// anki *trackedFile'a değil, böylece rotasyon sırasında dosya yeniden
+// açılsa bile serileştirme kesintiye uğramaz.
+func (t *fileTracker) processLock(path string) *sync.Mutex {
+	t.procMu.Lock()
+	defer t.procMu.Unlock()
+	mu, ok := t.procLock[path]
+	if !ok {
+		mu = &sync.Mutex{}
+		t.procLock[path] = mu
+	}
+	return mu
+}
+
+func (t *fileTracker) get(path string) (*trackedFile, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tf, ok := t.files[path]
+	return tf, ok
+}
+
+func (t *fileTracker) set(path string, tf *trackedFile) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.files[path] = tf
+}
+
+func (t *fileTracker) delete(path string) {
+	t.mu.Lock()
+	_, existed := t.files[path]
+	delete(t.files, path)
+	t.mu.Unlock()
+	if existed && t.metrics != nil {
+		t.metrics.FilesWatched.Dec()
+	}
+}
+
+func (t *fileTracker) paths() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]string, 0, len(t.files))
+	for p := range t.files {
+		out = append(out, p)
+	}
+	return out
+}
+
+// backlogBytes, tüm izlenen dosyalar için (mevcut dosya boyutu - okuma
+// pozisyonu) toplamını hesaplar; bu, ScannerBacklogBytes metriğini besler.
+func (t *fileTracker) backlogBytes() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var total int64
+	for path, tf := range t.files {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if backlog := info.Size() - tf.loadPos(); backlog > 0 {
+			total += backlog
+		}
+	}
+	return total
+}
+
+// openTracked, verilen dosyayı açar ve tracker'a ekler. parser ve filterChain,
+// bu dosyayla eşleşen LogPattern'den gelir (dolayısıyla isteğe bağlı olarak
+// farklı desenler farklı ayrıştırıcı/filtre kullanabilir).
+//
+// Okumaya başlanacak pozisyon şu öncelikle belirlenir: checkpoints içinde bu
+// yol için bir kayıt varsa ve kaydın (dev, ino) çifti dosyanın şu anki
+// inode'uyla eşleşiyorsa, toplayıcı kaldığı yerden devam eder. Aksi halde,
+// fromBeginning true ise dosya baştan okunur (ör. --from-beginning ile ilk
+// dolgu); yoksa eski sezgiye geri dönülüp sona konumlanılır, böylece
+// checkpoint'i olmayan mevcut dosyalar yeniden baştan işlenmez.
+func openTracked(tracker *fileTracker, filePath, serviceLabelTemplate string, parser parsers.Parser, filterChain filters.Chain, checkpoints *checkpointStore, fromBeginning bool) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		slog.Error("dosya açılamadı", "path", filePath, "error", err)
+		return
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		slog.Error("dosya bilgisi alınamadı", "path", filePath, "error", err)
+		file.Close()
+		return
+	}
+
+	dev, ino, statOK := statInode(info)
+
+	startPos := info.Size()
+	if fromBeginning {
+		startPos = 0
+	}
+	if checkpoints != nil {
+		if rec, ok := checkpoints.get(filePath); ok && statOK && rec.Dev == dev && rec.Ino == ino && rec.Pos <= info.Size() {
+			startPos = rec.Pos
+		}
+	}
+	file.Seek(startPos, io.SeekStart)
+
+	tracker.set(filePath, &trackedFile{
+		f:            file,
+		ino:          ino,
+		dev:          dev,
+		pos:          startPos,
+		serviceLabel: serviceLabelTemplate,
+		parser:       parser,
+		filters:      filterChain,
+	})
+	if tracker.metrics != nil {
+		tracker.metrics.FilesWatched.Inc()
+	}
+	slog.Info("izlemeye başlandı", "path", filePath, "pos", startPos)
+}
+
+// isRotatedSuffix, verilen dosya adının config'teki RotatedSuffixes
+// listesinden biriyle bitip bitmediğini kontrol eder (ör. ".1", ".gz").
+func isRotatedSuffix(name string, suffixes []string) bool {
+	for _, s := range suffixes {
+		if strings.HasSuffix(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// drainAndClose, eski inode'a ait dosya tanıtıcısını EOF'a kadar okuyup
+// kapatır. Bu, rotasyon anında henüz flush edilmemiş son satırların
+// kaybolmasını engeller.
+func drainAndClose(tf *trackedFile, filePath string, onLine func(line string)) {
+	tf.f.Seek(tf.loadPos(), io.SeekStart)
+	scanner := bufio.NewScanner(tf.f)
+	for scanner.Scan() {
+		onLine(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		slog.Error("rotasyon sonrası eski dosya okunamadı", "path", filePath, "error", err)
+	}
+	tf.f.Close()
+}
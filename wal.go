@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/ca1ik/GO-Cloud/model"
+)
+
+// diskSpillWAL, kuyruk dolduğunda ya da sink kalıcı olarak hata verdiğinde
+// satırların kaybolmasını engellemek için onları düz bir dosyaya (JSON Lines)
+// yazan basit bir write-ahead log'dur. sinkQueue.drainLoop bu dosyadaki
+// girdileri periyodik olarak doğrudan sink'e yeniden gönderir; kuyruktaki
+// girdilere göre bir öncelikleri yoktur.
+type diskSpillWAL struct {
+	path string
+
+	mu sync.Mutex
+	f  *os.File
+}
+
+// newDiskSpillWAL, verilen yoldaki (yoksa oluşturularak) WAL dosyasını açar.
+func newDiskSpillWAL(path string) (*diskSpillWAL, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("wal: dosya açılamadı '%s': %w", path, err)
+	}
+	return &diskSpillWAL{path: path, f: f}, nil
+}
+
+// append, verilen girdiyi WAL'a yazar.
+func (w *diskSpillWAL) append(entry model.LogEntry) error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = w.f.Write(append(line, '\n'))
+	return err
+}
+
+// drain, WAL'daki tüm girdileri okuyup verilen fonksiyona iletir, ardından
+// dosyayı sıfırlar. Başarıyla iletilemeyen bir girdide durur ve kalan
+// girdileri dosyada bırakır.
+func (w *diskSpillWAL) drain(emit func(model.LogEntry) bool) error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := w.f.Seek(0, 0); err != nil {
+		return err
+	}
+
+	var remaining []model.LogEntry
+	scanner := bufio.NewScanner(w.f)
+	draining := true
+	for scanner.Scan() {
+		var entry model.LogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if draining && emit(entry) {
+			continue
+		}
+		draining = false
+		remaining = append(remaining, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if err := w.f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := w.f.Seek(0, 0); err != nil {
+		return err
+	}
+	for _, entry := range remaining {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			continue
+		}
+		if _, err := w.f.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *diskSpillWAL) close() error {
+	if w == nil {
+		return nil
+	}
+	return w.f.Close()
+}
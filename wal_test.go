@@ -0,0 +1,116 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ca1ik/GO-Cloud/model"
+)
+
+func newTestWAL(t *testing.T) *diskSpillWAL {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "wal.jsonl")
+	w, err := newDiskSpillWAL(path)
+	if err != nil {
+		t.Fatalf("newDiskSpillWAL: %v", err)
+	}
+	t.Cleanup(func() { w.close() })
+	return w
+}
+
+func TestDiskSpillWALDrainAllSucceed(t *testing.T) {
+	w := newTestWAL(t)
+
+	for _, msg := range []string{"a", "b", "c"} {
+		if err := w.append(model.LogEntry{Message: msg}); err != nil {
+			t.Fatalf("append(%q): %v", msg, err)
+		}
+	}
+
+	var got []string
+	if err := w.drain(func(e model.LogEntry) bool {
+		got = append(got, e.Message)
+		return true
+	}); err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+	if want := []string{"a", "b", "c"}; !equalStrings(got, want) {
+		t.Errorf("drained entries = %v, istenen %v", got, want)
+	}
+
+	// Tüm girdiler başarıyla iletildiğine göre dosya boşalmış olmalı.
+	var second []string
+	if err := w.drain(func(e model.LogEntry) bool {
+		second = append(second, e.Message)
+		return true
+	}); err != nil {
+		t.Fatalf("ikinci drain: %v", err)
+	}
+	if len(second) != 0 {
+		t.Errorf("ilk drain sonrası dosya truncate edilmemiş, kalan: %v", second)
+	}
+}
+
+func TestDiskSpillWALDrainStopsOnFirstRejection(t *testing.T) {
+	w := newTestWAL(t)
+
+	for _, msg := range []string{"a", "b", "c"} {
+		if err := w.append(model.LogEntry{Message: msg}); err != nil {
+			t.Fatalf("append(%q): %v", msg, err)
+		}
+	}
+
+	// "b"de reddederek kuyruğun tekrar dolu olduğu durumu simüle et; drain,
+	// "a"yı ilettikten sonra durmalı ve "b" ile "c"yi dosyada bırakmalı.
+	var emitted []string
+	if err := w.drain(func(e model.LogEntry) bool {
+		if e.Message == "b" {
+			return false
+		}
+		emitted = append(emitted, e.Message)
+		return true
+	}); err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+	if want := []string{"a"}; !equalStrings(emitted, want) {
+		t.Errorf("emit edilenler = %v, istenen %v", emitted, want)
+	}
+
+	// İlk reddedilen girdiden (b) sonrasına hiç bakılmaz; drain "c"yi emit
+	// etmeye çalışmadan doğrudan dosyaya geri yazar. Bunu, bu turda emit
+	// çağrılan girdileri izleyerek doğrula.
+	var triedThisRound []string
+	if err := w.drain(func(e model.LogEntry) bool {
+		triedThisRound = append(triedThisRound, e.Message)
+		return false
+	}); err != nil {
+		t.Fatalf("ikinci drain: %v", err)
+	}
+	if want := []string{"b"}; !equalStrings(triedThisRound, want) {
+		t.Errorf("bu turda denenen girdiler = %v, istenen %v (c denenmemeliydi)", triedThisRound, want)
+	}
+
+	// b ve c hâlâ (sırasıyla) dosyada kalmış olmalı.
+	var remaining []string
+	if err := w.drain(func(e model.LogEntry) bool {
+		remaining = append(remaining, e.Message)
+		return true
+	}); err != nil {
+		t.Fatalf("üçüncü drain: %v", err)
+	}
+	if want := []string{"b", "c"}; !equalStrings(remaining, want) {
+		t.Errorf("dosyada kalanlar = %v, istenen %v", remaining, want)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}